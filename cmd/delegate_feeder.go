@@ -0,0 +1,164 @@
+package cmd
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/NibiruChain/nibiru/app"
+	"github.com/NibiruChain/pricefeeder/config"
+	"github.com/NibiruChain/pricefeeder/feeder/priceposter"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/rs/zerolog"
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+)
+
+// delegateFeederTimeout bounds the delegation tx, from signing to its inclusion in a block.
+const delegateFeederTimeout = 1 * time.Minute
+
+func init() {
+	delegateFeederCmd.Flags().Bool("check", false, "only check that the on-chain feeder delegation matches the configuration")
+	rootCmd.AddCommand(delegateFeederCmd)
+}
+
+// delegateFeederCmd sends the MsgDelegateFeedConsent which allows the configured feeder
+// to post prices on behalf of the validator, or checks the current delegation.
+var delegateFeederCmd = &cobra.Command{
+	Use:   "delegate-feeder",
+	Short: "Delegate price feeding from the validator to the feeder account",
+	Long: `Delegate price feeding from the validator to the configured feeder account.
+The tx is signed by the validator's operator key, whose mnemonic is read from the
+VALIDATOR_MNEMONIC env variable or, if unset, from stdin, and derived at the path set by the
+VALIDATOR_HD_* env variables with the VALIDATOR_BIP39_PASSPHRASE.
+
+The feeder account delegated to is the one configured for the validator, in VALIDATORS_FILE if set.
+With --check, only verifies that every configured validator delegated price feeding to its feeder account.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		logger := zerolog.New(os.Stderr).With().Timestamp().Logger()
+		app.SetPrefixes(app.AccountAddressPrefix)
+
+		c, err := config.Get()
+		if err != nil {
+			return err
+		}
+		tlsConfig, err := c.GRPCTLSConfig()
+		if err != nil {
+			return err
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), delegateFeederTimeout)
+		defer cancel()
+
+		if check, _ := cmd.Flags().GetBool("check"); check {
+			for i, v := range c.ValidatorConfigs() {
+				if err := checkDelegation(ctx, c, v, tlsConfig, logger); err != nil {
+					if len(c.Validators) > 0 {
+						return fmt.Errorf("validator %d of VALIDATORS_FILE: %w", i, err)
+					}
+					return err
+				}
+			}
+			return nil
+		}
+
+		mnemonic, err := validatorMnemonic()
+		if err != nil {
+			return err
+		}
+		validatorKb, valAddr, operatorAddr, err := config.GetAuthWithHDPath(mnemonic, c.ValidatorKey.BIP39Passphrase, c.ValidatorKey.HDPath())
+		if err != nil {
+			return err
+		}
+		v, err := validatorConfig(c, valAddr)
+		if err != nil {
+			return err
+		}
+		_, _, feederAddr, err := v.FeederAuth()
+		if err != nil {
+			return err
+		}
+
+		// the operator account signs the delegation and pays its fees
+		pricePoster := priceposter.Dial(c.GRPCEndpoint, c.ChainID, tlsConfig, c.Fees.WithoutGranter(), validatorKb, valAddr, operatorAddr, logger)
+		defer pricePoster.Close()
+		resp, err := pricePoster.DelegateFeeder(ctx, feederAddr)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("validator %s delegated price feeding to %s in tx %s\n", valAddr, feederAddr, resp.TxHash)
+		return nil
+	},
+}
+
+// checkDelegation checks that the validator delegated price feeding to its configured feeder account.
+func checkDelegation(ctx context.Context, c *config.Config, v config.ValidatorConfig, tlsConfig *tls.Config, logger zerolog.Logger) error {
+	feederKb, valAddr, feederAddr, err := v.FeederAuth()
+	if err != nil {
+		return err
+	}
+	if v.ValidatorAddr == nil {
+		fmt.Printf("validator %s posts its own prices, no delegation to check\n", valAddr)
+		return nil
+	}
+
+	pricePoster := priceposter.Dial(c.GRPCEndpoint, c.ChainID, tlsConfig, c.Fees, feederKb, *v.ValidatorAddr, feederAddr, logger)
+	defer pricePoster.Close()
+	if err := pricePoster.CheckFeederDelegation(ctx); err != nil {
+		return err
+	}
+	fmt.Printf("validator %s delegated price feeding to %s\n", v.ValidatorAddr, feederAddr)
+	return nil
+}
+
+// validatorConfig returns the configured validator the validator mnemonic belongs to.
+func validatorConfig(c *config.Config, valAddr sdk.ValAddress) (config.ValidatorConfig, error) {
+	validators := c.ValidatorConfigs()
+	for _, v := range validators {
+		if v.ValidatorAddr != nil && v.ValidatorAddr.Equals(valAddr) {
+			return v, nil
+		}
+	}
+	if len(c.Validators) > 0 {
+		return config.ValidatorConfig{}, fmt.Errorf("the validator mnemonic belongs to %s, which is not in VALIDATORS_FILE", valAddr)
+	}
+	if c.ValidatorAddr != nil {
+		return config.ValidatorConfig{}, fmt.Errorf("the validator mnemonic belongs to %s, not to VALIDATOR_ADDRESS %s", valAddr, c.ValidatorAddr)
+	}
+	return validators[0], nil
+}
+
+// validatorMnemonic returns the validator mnemonic from the VALIDATOR_MNEMONIC env variable,
+// prompting for it on stdin if the variable is unset. A mnemonic typed in a terminal is not echoed.
+func validatorMnemonic() (string, error) {
+	if mnemonic := os.Getenv("VALIDATOR_MNEMONIC"); mnemonic != "" {
+		return mnemonic, nil
+	}
+
+	var mnemonic string
+	if fd := int(os.Stdin.Fd()); term.IsTerminal(fd) {
+		fmt.Fprint(os.Stderr, "Enter the validator mnemonic: ")
+		b, err := term.ReadPassword(fd)
+		fmt.Fprintln(os.Stderr)
+		if err != nil {
+			return "", fmt.Errorf("failed to read the validator mnemonic: %w", err)
+		}
+		mnemonic = string(b)
+	} else {
+		// piped input
+		line, err := bufio.NewReader(os.Stdin).ReadString('\n')
+		if err != nil {
+			return "", fmt.Errorf("failed to read the validator mnemonic: %w", err)
+		}
+		mnemonic = line
+	}
+	mnemonic = strings.TrimSpace(mnemonic)
+	if mnemonic == "" {
+		return "", fmt.Errorf("no validator mnemonic")
+	}
+	return mnemonic, nil
+}
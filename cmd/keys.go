@@ -0,0 +1,59 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/NibiruChain/nibiru/app"
+	"github.com/NibiruChain/pricefeeder/config"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	keysCmd.AddCommand(keysShowCmd)
+	rootCmd.AddCommand(keysCmd)
+}
+
+// keysCmd groups the subcommands inspecting the feeder key.
+var keysCmd = &cobra.Command{
+	Use:   "keys",
+	Short: "Inspect the feeder key",
+}
+
+// keysShowCmd prints the addresses of the configured feeder keys, so that the key
+// configuration can be checked before starting the daemon.
+var keysShowCmd = &cobra.Command{
+	Use:   "show",
+	Short: "Show the feeder and validator addresses of the configured feeder keys",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		app.SetPrefixes(app.AccountAddressPrefix)
+
+		c, err := config.Get()
+		if err != nil {
+			return err
+		}
+
+		out := cmd.OutOrStdout()
+		for i, v := range c.ValidatorConfigs() {
+			_, valAddr, feederAddr, err := v.FeederAuth()
+			if err != nil {
+				if len(c.Validators) > 0 {
+					return fmt.Errorf("validator %d of VALIDATORS_FILE: %w", i, err)
+				}
+				return err
+			}
+
+			if i > 0 {
+				fmt.Fprintln(out)
+			}
+			fmt.Fprintf(out, "feeder:    %s\n", feederAddr)
+			fmt.Fprintf(out, "validator: %s\n", valAddr)
+			if v.ValidatorAddr != nil && !v.ValidatorAddr.Equals(valAddr) {
+				fmt.Fprintf(out, "delegated: %s\n", v.ValidatorAddr)
+			}
+			if v.Keyring.Backend == config.KeyringBackendMnemonic {
+				fmt.Fprintf(out, "hd path:   %s\n", v.Keyring.HDPath().String())
+			}
+		}
+		return nil
+	},
+}
@@ -0,0 +1,58 @@
+package cmd
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/NibiruChain/pricefeeder/feeder/leader"
+	"github.com/rs/zerolog"
+	"github.com/spf13/cobra"
+)
+
+// leaseServerShutdownTimeout bounds the time given to in-flight lease requests on shutdown.
+const leaseServerShutdownTimeout = 5 * time.Second
+
+func init() {
+	leaseServerCmd.Flags().String("listen", "127.0.0.1:8091", "address to listen on")
+	rootCmd.AddCommand(leaseServerCmd)
+}
+
+// leaseServerCmd runs an in-memory lease server for feeders configured with the lease leader election.
+var leaseServerCmd = &cobra.Command{
+	Use:   "lease-server",
+	Short: "Run a lease server electing the leader among feeders",
+	Long: `Run an in-memory lease server for feeders configured with LEADER_ELECTION=lease, which
+set LEADER_LEASE_URL to http://<listen>/v1/leases/<name>, one name per set of validators.
+
+The leases are lost when the server restarts, and feeders stand by while it is unreachable.
+It serves plain HTTP and should only be used on a trusted network.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		logger := zerolog.New(os.Stderr).With().Timestamp().Logger()
+
+		listen, _ := cmd.Flags().GetString("listen")
+		server := &http.Server{
+			Addr:              listen,
+			Handler:           leader.NewLeaseServer(logger).Handler(),
+			ReadHeaderTimeout: 10 * time.Second,
+		}
+
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
+		go func() {
+			<-ctx.Done()
+			shutdownCtx, cancel := context.WithTimeout(context.Background(), leaseServerShutdownTimeout)
+			defer cancel()
+			_ = server.Shutdown(shutdownCtx)
+		}()
+
+		logger.Info().Str("listen", listen).Msg("lease server started")
+		if err := server.ListenAndServe(); err != http.ErrServerClosed {
+			return err
+		}
+		return nil
+	},
+}
@@ -0,0 +1,109 @@
+package cmd
+
+import (
+	"os"
+	"os/signal"
+	"path/filepath"
+	"syscall"
+	"time"
+
+	"github.com/NibiruChain/pricefeeder/config"
+	"github.com/NibiruChain/pricefeeder/feeder/priceprovider"
+	"github.com/NibiruChain/pricefeeder/metrics"
+	"github.com/fsnotify/fsnotify"
+	"github.com/rs/zerolog"
+)
+
+// priceSourcesReloadDelay groups the burst of events an editor or a config map update
+// makes when replacing the PRICE_SOURCES_FILE into a single reload.
+var priceSourcesReloadDelay = 500 * time.Millisecond
+
+// configMapDataLink is the symlink a Kubernetes config map volume atomically swaps on updates,
+// through which the file of the config map is linked.
+const configMapDataLink = "..data"
+
+// watchPriceSources reloads the price sources on SIGHUP and whenever the PRICE_SOURCES_FILE changes.
+// A configuration which fails to load or to validate is logged and the current one is kept.
+// Without PRICE_SOURCES_FILE, SIGHUP is still handled so that it doesn't kill the feeder,
+// but only logged since the env vars read at startup can't have changed.
+// The watch stops once done is closed.
+func watchPriceSources(c *config.Config, priceProvider *priceprovider.AggregatePriceProvider, done <-chan struct{}, logger zerolog.Logger) {
+	logger = logger.With().Str("component", "price-sources-watcher").Logger()
+	hangup := make(chan os.Signal, 1)
+	signal.Notify(hangup, syscall.SIGHUP)
+
+	var watcher *fsnotify.Watcher
+	var fileEvents <-chan fsnotify.Event
+	var fileErrors <-chan error
+	if c.PriceSourcesFile != "" {
+		var err error
+		watcher, err = fsnotify.NewWatcher()
+		if err != nil {
+			panic(err)
+		}
+		// the directory is watched since the file is usually replaced rather than written to,
+		// which would end a watch on the file itself
+		if err := watcher.Add(filepath.Dir(c.PriceSourcesFile)); err != nil {
+			panic(err)
+		}
+		fileEvents, fileErrors = watcher.Events, watcher.Errors
+	}
+
+	go func() {
+		defer signal.Stop(hangup)
+		if watcher != nil {
+			defer watcher.Close()
+		}
+
+		delay := time.NewTimer(0)
+		<-delay.C
+		defer delay.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-hangup:
+				if c.PriceSourcesFile == "" {
+					logger.Warn().Msg("received SIGHUP but PRICE_SOURCES_FILE is not set, nothing to reload")
+					continue
+				}
+				logger.Info().Msg("received SIGHUP, reloading the price sources")
+				reloadPriceSources(c, priceProvider, logger)
+			case event := <-fileEvents:
+				if isPriceSourcesChange(c.PriceSourcesFile, event) {
+					delay.Reset(priceSourcesReloadDelay)
+				}
+			case <-delay.C:
+				logger.Info().Str("file", c.PriceSourcesFile).Msg("price sources file changed, reloading the price sources")
+				reloadPriceSources(c, priceProvider, logger)
+			case err := <-fileErrors:
+				logger.Err(err).Msg("failed to watch the price sources file")
+			}
+		}
+	}()
+}
+
+// isPriceSourcesChange reports whether the event of the directory of the price sources file changes the file:
+// either an event of the file itself, or the swap of the symlink through which a config map links it.
+func isPriceSourcesChange(file string, event fsnotify.Event) bool {
+	name := filepath.Clean(event.Name)
+	if name == filepath.Clean(file) {
+		return true
+	}
+	return event.Has(fsnotify.Create) && name == filepath.Join(filepath.Dir(file), configMapDataLink)
+}
+
+// reloadPriceSources reads the price sources again and applies them to the price provider.
+func reloadPriceSources(c *config.Config, priceProvider *priceprovider.AggregatePriceProvider, logger zerolog.Logger) {
+	exchangeSymbolsMap, datasourceConfigMap, err := config.ReadPriceSources(c.PriceSourcesFile)
+	if err == nil {
+		err = priceProvider.Reload(exchangeSymbolsMap, datasourceConfigMap)
+	}
+	if err != nil {
+		logger.Err(err).Msg("invalid price sources, keeping the current ones")
+		metrics.PriceSourcesReloads.WithLabelValues("false").Inc()
+		return
+	}
+	logger.Info().Msg("price sources reloaded")
+	metrics.PriceSourcesReloads.WithLabelValues("true").Inc()
+}
@@ -0,0 +1,115 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/NibiruChain/nibiru/app"
+	"github.com/NibiruChain/pricefeeder/config"
+	"github.com/NibiruChain/pricefeeder/feeder/remotesigner"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/rs/zerolog"
+	"github.com/spf13/cobra"
+)
+
+// remoteSignerShutdownTimeout bounds the time given to in-flight sign requests on shutdown.
+const remoteSignerShutdownTimeout = 5 * time.Second
+
+func init() {
+	remoteSignerCmd.Flags().String("listen", "127.0.0.1:8090", "address to listen on")
+	remoteSignerCmd.Flags().String("tls-cert", "", "server certificate file")
+	remoteSignerCmd.Flags().String("tls-key", "", "server key file")
+	remoteSignerCmd.Flags().String("client-ca", "", "CA file the client certificates are verified against")
+	remoteSignerCmd.Flags().StringSlice("allowed-msgs", remotesigner.DefaultAllowedMsgs, "type URLs of the msgs allowed in signed txs")
+	remoteSignerCmd.Flags().String("max-fee", remotesigner.DefaultMaxFee, "highest fee of the signed txs, empty for no maximum")
+	rootCmd.AddCommand(remoteSignerCmd)
+}
+
+// remoteSignerCmd runs a reference remote signer, holding the feeder key on behalf of feeders
+// configured with the remote keyring backend.
+var remoteSignerCmd = &cobra.Command{
+	Use:   "remote-signer",
+	Short: "Run a remote signer holding the feeder key",
+	Long: `Run a remote signer holding the feeder key, loaded from the keyring backend configured in the .env.
+Only txs for CHAIN_ID made of the allowed msgs, by default the oracle prevote and vote, and
+whose fee is at most --max-fee are signed.
+
+With --tls-cert, --tls-key and --client-ca the signer serves over mutual TLS and only accepts
+clients whose certificate is signed by the client CA. Otherwise it serves plain HTTP, which
+should only be used on a trusted network.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		logger := zerolog.New(os.Stderr).With().Timestamp().Logger()
+		app.SetPrefixes(app.AccountAddressPrefix)
+
+		c, err := config.Get()
+		if err != nil {
+			return err
+		}
+		if c.Keyring.Backend == config.KeyringBackendRemote {
+			return fmt.Errorf("the remote signer cannot use the remote keyring backend")
+		}
+		kb, _, feederAddr, err := c.FeederAuth()
+		if err != nil {
+			return err
+		}
+
+		allowedMsgs, _ := cmd.Flags().GetStringSlice("allowed-msgs")
+		maxFeeFlag, _ := cmd.Flags().GetString("max-fee")
+		maxFee, err := sdk.ParseCoinsNormalized(maxFeeFlag)
+		if err != nil {
+			return fmt.Errorf("invalid --max-fee: %w", err)
+		}
+		signer, err := remotesigner.NewServer(kb, feederAddr, c.ChainID, allowedMsgs, maxFee, logger)
+		if err != nil {
+			return err
+		}
+
+		listen, _ := cmd.Flags().GetString("listen")
+		server := &http.Server{
+			Addr:              listen,
+			Handler:           signer.Handler(),
+			ReadHeaderTimeout: 10 * time.Second,
+		}
+
+		certFile, _ := cmd.Flags().GetString("tls-cert")
+		keyFile, _ := cmd.Flags().GetString("tls-key")
+		clientCAFile, _ := cmd.Flags().GetString("client-ca")
+		useTLS := certFile != "" || keyFile != "" || clientCAFile != ""
+		if useTLS {
+			if certFile == "" || keyFile == "" || clientCAFile == "" {
+				return fmt.Errorf("--tls-cert, --tls-key and --client-ca must be set together")
+			}
+			server.TLSConfig, err = remotesigner.NewServerTLSConfig(certFile, keyFile, clientCAFile)
+			if err != nil {
+				return err
+			}
+		} else {
+			logger.Warn().Msg("serving without TLS, any client able to connect can get txs signed")
+		}
+
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
+		go func() {
+			<-ctx.Done()
+			shutdownCtx, cancel := context.WithTimeout(context.Background(), remoteSignerShutdownTimeout)
+			defer cancel()
+			_ = server.Shutdown(shutdownCtx)
+		}()
+
+		logger.Info().Str("listen", listen).Str("address", feederAddr.String()).Strs("allowed-msgs", allowedMsgs).Msg("remote signer started")
+		if useTLS {
+			err = server.ListenAndServeTLS("", "")
+		} else {
+			err = server.ListenAndServe()
+		}
+		if err == http.ErrServerClosed {
+			return nil
+		}
+		return err
+	},
+}
@@ -1,18 +1,24 @@
 package cmd
 
 import (
+	"context"
+	"crypto/tls"
 	"flag"
 	"fmt"
 	"net/http"
 	"os"
 	"os/signal"
+	"time"
 
 	"github.com/NibiruChain/nibiru/app"
 	"github.com/NibiruChain/pricefeeder/config"
 	"github.com/NibiruChain/pricefeeder/feeder"
 	"github.com/NibiruChain/pricefeeder/feeder/eventstream"
+	"github.com/NibiruChain/pricefeeder/feeder/leader"
 	"github.com/NibiruChain/pricefeeder/feeder/priceposter"
 	"github.com/NibiruChain/pricefeeder/feeder/priceprovider"
+	"github.com/NibiruChain/pricefeeder/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"github.com/rs/zerolog"
 	"github.com/spf13/cobra"
@@ -34,18 +40,99 @@ func setupLogger() zerolog.Logger {
 }
 
 // handleInterrupt listens for SIGINT and gracefully shuts down the feeder.
+// It also exits the process if the feeder decides to stop by itself.
 func handleInterrupt(logger zerolog.Logger, f *feeder.Feeder) {
 	interrupt := make(chan os.Signal, 1)
 	signal.Notify(interrupt, os.Interrupt)
 
 	go func() {
-		<-interrupt
-		logger.Info().Msg("shutting down gracefully")
-		f.Close()
+		select {
+		case <-interrupt:
+			logger.Info().Msg("shutting down gracefully")
+			f.Close()
+		case <-f.Done():
+			logger.Error().Msg("feeder stopped")
+		}
 		os.Exit(1)
 	}()
 }
 
+// checkFeederDelegation logs an error if the feeder is not allowed to post prices for the validator.
+// The feeder still starts, since the delegation can be sent while it runs.
+func checkFeederDelegation(pricePoster *priceposter.Client, logger zerolog.Logger) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	if err := pricePoster.CheckFeederDelegation(ctx); err != nil {
+		logger.Err(err).Msg("feeder delegation check failed, see the delegate-feeder command")
+	}
+}
+
+// checkFeeGrant panics if the fee granter cannot pay the fees of the feeder,
+// since every tx would then be rejected.
+func checkFeeGrant(pricePoster *priceposter.Client) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	if err := pricePoster.CheckFeeGrant(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// dialPricePoster connects the price poster of a validator and runs its startup checks.
+// It returns the price poster along with the feeder address signing its txs.
+func dialPricePoster(c *config.Config, v config.ValidatorConfig, tlsConfig *tls.Config, logger zerolog.Logger) (*priceposter.Client, sdk.AccAddress) {
+	kb, valAddr, feederAddr, err := v.FeederAuth()
+	if err != nil {
+		panic(err)
+	}
+	if v.ValidatorAddr != nil {
+		valAddr = *v.ValidatorAddr
+	}
+	if len(c.Validators) > 0 {
+		logger = logger.With().Str("validator", valAddr.String()).Logger()
+	}
+
+	pricePoster := priceposter.Dial(c.GRPCEndpoint, c.ChainID, tlsConfig, c.Fees, kb, valAddr, feederAddr, logger)
+	if v.ValidatorAddr != nil {
+		checkFeederDelegation(pricePoster, logger)
+	}
+	if !c.Fees.Granter.Empty() {
+		checkFeeGrant(pricePoster)
+	}
+	if v.PrevoteStateFile != "" {
+		if err := pricePoster.LoadPrevoteState(v.PrevoteStateFile); err != nil {
+			panic(err)
+		}
+	}
+	if c.MissCounterInterval > 0 {
+		pricePoster.StartMissCounterMonitor(c.MissCounterInterval)
+	}
+	if c.BalanceInterval > 0 {
+		pricePoster.StartBalanceMonitor(c.BalanceInterval, c.BalanceThresholds)
+	}
+	if c.DryRunFile != "" {
+		if err := pricePoster.EnableDryRun(c.DryRunFile, c.DryRunSimulate); err != nil {
+			panic(err)
+		}
+	}
+	return pricePoster, feederAddr
+}
+
+// newLeaderElection starts the leader election configured by LEADER_ELECTION, or returns nil if disabled.
+func newLeaderElection(c config.LeaderElectionConfig, logger zerolog.Logger) types.LeaderElection {
+	switch c.Backend {
+	case config.LeaderElectionFile:
+		election, err := leader.NewFileLock(c.LockFile, c.ID, logger)
+		if err != nil {
+			panic(err)
+		}
+		return election
+	case config.LeaderElectionLease:
+		return leader.NewLease(c.LeaseURL, c.ID, c.LeaseTTL, logger)
+	default:
+		return nil
+	}
+}
+
 // rootCmd is the main command for the pricefeeder CLI.
 // It starts the pricefeeder service and its required components:
 // - event stream (for blockchain connectivity)
@@ -60,20 +147,43 @@ var rootCmd = &cobra.Command{
 		app.SetPrefixes(app.AccountAddressPrefix)
 
 		c := config.MustGet()
+		feeder.MaxDisconnectedTime = c.MaxDisconnectedTime
+
+		tlsConfig, err := c.GRPCTLSConfig()
+		if err != nil {
+			panic(err)
+		}
 
-		eventStream := eventstream.Dial(c.WebsocketEndpoint, c.GRPCEndpoint, c.EnableTLS, logger)
+		eventStream := eventstream.Dial(c.WebsocketEndpoint, c.GRPCEndpoint, c.EventSubscription, tlsConfig, logger)
 		priceProvider := priceprovider.NewAggregatePriceProvider(c.ExchangesToPairToSymbolMap, c.DataSourceConfigMap, logger)
-		kb, valAddr, feederAddr := config.GetAuth(c.FeederMnemonic)
+		var pricePosters []types.PricePoster
+		inUse := map[string]bool{}
+		for _, v := range c.ValidatorConfigs() {
+			pricePoster, feederAddr := dialPricePoster(c, v, tlsConfig, logger)
+			// each client tracks the prevote of its validator and the sequence of its feeder account
+			for _, addr := range []string{pricePoster.Whoami().String(), feederAddr.String()} {
+				if inUse[addr] {
+					panic(fmt.Sprintf("%s is configured for several validators", addr))
+				}
+				inUse[addr] = true
+			}
+			pricePosters = append(pricePosters, pricePoster)
+		}
+		if c.DryRunFile != "" {
+			logger.Warn().Str("file", c.DryRunFile).Msg("dry run enabled, txs are recorded but never broadcast")
+		}
 
-		if c.ValidatorAddr != nil {
-			valAddr = *c.ValidatorAddr
+		leaderElection := newLeaderElection(c.LeaderElection, logger)
+		if leaderElection != nil {
+			logger.Info().Str("backend", c.LeaderElection.Backend).Str("id", c.LeaderElection.ID).Msg("standing by until elected leader")
 		}
-		pricePoster := priceposter.Dial(c.GRPCEndpoint, c.ChainID, c.EnableTLS, kb, valAddr, feederAddr, logger)
 
-		f := feeder.NewFeeder(eventStream, priceProvider, pricePoster, logger)
+		f := feeder.NewFeeder(eventStream, priceProvider, pricePosters, leaderElection, logger)
 		f.Run()
 		defer f.Close()
 
+		watchPriceSources(c, priceProvider, f.Done(), logger)
+
 		handleInterrupt(logger, f)
 
 		metricsPort := os.Getenv("METRICS_PORT")
@@ -0,0 +1,190 @@
+package config
+
+import (
+	"crypto/tls"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/NibiruChain/nibiru/app"
+	"github.com/NibiruChain/pricefeeder/feeder/remotesigner"
+	"github.com/cosmos/cosmos-sdk/crypto"
+	"github.com/cosmos/cosmos-sdk/crypto/hd"
+	"github.com/cosmos/cosmos-sdk/crypto/keyring"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// Backends the feeder key can be loaded from.
+const (
+	// KeyringBackendMnemonic derives the key from FEEDER_MNEMONIC.
+	KeyringBackendMnemonic = "mnemonic"
+	// KeyringBackendFile loads the key from an encrypted Cosmos SDK file keyring.
+	KeyringBackendFile = keyring.BackendFile
+	// KeyringBackendTest loads the key from an unencrypted Cosmos SDK test keyring.
+	KeyringBackendTest = keyring.BackendTest
+	// KeyringBackendArmor loads the key from an armored private key file, as exported by `nibid keys export`.
+	KeyringBackendArmor = "armor"
+	// KeyringBackendHex loads the key from a hex encoded secp256k1 private key.
+	KeyringBackendHex = "hex"
+	// KeyringBackendRemote signs with a key held by a remote signer, see the remotesigner package.
+	KeyringBackendRemote = "remote"
+)
+
+// keyringAppName is the name of the Cosmos SDK keyring service, the one used by nibid.
+const keyringAppName = "nibi"
+
+// KeyringConfig selects where the feeder key is loaded from.
+type KeyringConfig struct {
+	Backend string `json:"backend"`
+
+	// BIP44 path and BIP39 passphrase the key is derived from the mnemonic with,
+	// m/44'/CoinType'/Account'/0/AddressIndex.
+	CoinType        uint32 `json:"coin_type"`
+	Account         uint32 `json:"account"`
+	AddressIndex    uint32 `json:"address_index"`
+	BIP39Passphrase string `json:"bip39_passphrase"`
+
+	Dir            string `json:"dir"`             // directory of the file and test keyrings
+	KeyName        string `json:"key_name"`        // name of the key in the file and test keyrings
+	PassphraseFile string `json:"passphrase_file"` // passphrase of the file keyring or of the armored key
+	ArmoredKeyFile string `json:"armored_key_file"`
+	PrivateKeyHex  string `json:"private_key_hex"`
+
+	RemoteSignerURL      string `json:"remote_signer_url"`
+	RemoteSignerCAFile   string `json:"remote_signer_ca_file"`   // CA of the remote signer's certificate
+	RemoteSignerCertFile string `json:"remote_signer_cert_file"` // client certificate presented to the remote signer
+	RemoteSignerKeyFile  string `json:"remote_signer_key_file"`
+}
+
+// Validate returns an error if the key source of the backend is not configured.
+func (c KeyringConfig) Validate(mnemonic string) error {
+	switch c.Backend {
+	case KeyringBackendMnemonic:
+		if mnemonic == "" {
+			return fmt.Errorf("no feeder mnemonic")
+		}
+	case KeyringBackendFile, KeyringBackendTest:
+		if c.Dir == "" || c.KeyName == "" {
+			return fmt.Errorf("KEYRING_DIR and KEYRING_KEY_NAME are required by the %s keyring backend", c.Backend)
+		}
+		if c.Backend == KeyringBackendFile && c.PassphraseFile == "" {
+			return fmt.Errorf("KEYRING_PASSPHRASE_FILE is required by the file keyring backend")
+		}
+	case KeyringBackendArmor:
+		if c.ArmoredKeyFile == "" || c.PassphraseFile == "" {
+			return fmt.Errorf("ARMORED_KEY_FILE and KEYRING_PASSPHRASE_FILE are required by the armor keyring backend")
+		}
+	case KeyringBackendHex:
+		if c.PrivateKeyHex == "" {
+			return fmt.Errorf("FEEDER_PRIVATE_KEY is required by the hex keyring backend")
+		}
+	case KeyringBackendRemote:
+		if c.RemoteSignerURL == "" {
+			return fmt.Errorf("REMOTE_SIGNER_URL is required by the remote keyring backend")
+		}
+		allSet := c.RemoteSignerCAFile != "" && c.RemoteSignerCertFile != "" && c.RemoteSignerKeyFile != ""
+		noneSet := c.RemoteSignerCAFile == "" && c.RemoteSignerCertFile == "" && c.RemoteSignerKeyFile == ""
+		if !allSet && !noneSet {
+			return fmt.Errorf("REMOTE_SIGNER_CA_FILE, REMOTE_SIGNER_CERT_FILE and REMOTE_SIGNER_KEY_FILE must be set together")
+		}
+	default:
+		return fmt.Errorf("unsupported keyring backend %q", c.Backend)
+	}
+	return nil
+}
+
+// HDPath returns the BIP44 path the key is derived from the mnemonic at.
+func (c KeyringConfig) HDPath() hd.BIP44Params {
+	return *hd.NewFundraiserParams(c.Account, c.CoinType, c.AddressIndex)
+}
+
+// FeederAuth loads the feeder key from the configured keyring backend, and returns
+// a keyring holding it along with its address and the matching validator address.
+func (c *Config) FeederAuth() (keyring.Keyring, sdk.ValAddress, sdk.AccAddress, error) {
+	return c.Keyring.auth(c.FeederMnemonic)
+}
+
+// auth loads the feeder key from the keyring backend, the mnemonic is only used by the mnemonic backend.
+func (c KeyringConfig) auth(mnemonic string) (keyring.Keyring, sdk.ValAddress, sdk.AccAddress, error) {
+	switch c.Backend {
+	case KeyringBackendMnemonic:
+		return GetAuthWithHDPath(mnemonic, c.BIP39Passphrase, c.HDPath())
+	case KeyringBackendFile, KeyringBackendTest:
+		return c.sdkKeyring()
+	case KeyringBackendArmor:
+		armor, err := os.ReadFile(c.ArmoredKeyFile)
+		if err != nil {
+			return nil, nil, nil, fmt.Errorf("failed to read ARMORED_KEY_FILE: %w", err)
+		}
+		passphrase, err := readPassphrase(c.PassphraseFile)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		privKey, _, err := crypto.UnarmorDecryptPrivKey(string(armor), passphrase)
+		if err != nil {
+			return nil, nil, nil, fmt.Errorf("failed to decrypt ARMORED_KEY_FILE: %w", err)
+		}
+		kr := newPrivKeyKeyring(hex.EncodeToString(privKey.Bytes()))
+		return kr, sdk.ValAddress(kr.addr), kr.addr, nil
+	case KeyringBackendHex:
+		b, err := hex.DecodeString(strings.TrimPrefix(c.PrivateKeyHex, "0x"))
+		if err != nil || len(b) != 32 {
+			return nil, nil, nil, fmt.Errorf("FEEDER_PRIVATE_KEY must be a 32 bytes hex encoded private key")
+		}
+		kr := newPrivKeyKeyring(hex.EncodeToString(b))
+		return kr, sdk.ValAddress(kr.addr), kr.addr, nil
+	case KeyringBackendRemote:
+		var tlsConfig *tls.Config
+		if c.RemoteSignerCAFile != "" {
+			var err error
+			tlsConfig, err = remotesigner.NewClientTLSConfig(c.RemoteSignerCAFile, c.RemoteSignerCertFile, c.RemoteSignerKeyFile)
+			if err != nil {
+				return nil, nil, nil, err
+			}
+		}
+		kr, err := remotesigner.Dial(c.RemoteSignerURL, tlsConfig)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		return kr, sdk.ValAddress(kr.Address()), kr.Address(), nil
+	default:
+		return nil, nil, nil, fmt.Errorf("unsupported keyring backend %q", c.Backend)
+	}
+}
+
+// sdkKeyring opens the Cosmos SDK keyring and looks up the feeder key.
+func (c KeyringConfig) sdkKeyring() (keyring.Keyring, sdk.ValAddress, sdk.AccAddress, error) {
+	// the file keyring reads its passphrase from the user input
+	var userInput strings.Builder
+	if c.Backend == KeyringBackendFile {
+		passphrase, err := readPassphrase(c.PassphraseFile)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		userInput.WriteString(passphrase + "\n")
+	}
+
+	kb, err := keyring.New(keyringAppName, c.Backend, c.Dir, strings.NewReader(userInput.String()), app.MakeEncodingConfig().Marshaler)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	record, err := kb.Key(c.KeyName)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to load key %q: %w", c.KeyName, err)
+	}
+	addr, err := record.GetAddress()
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	return kb, sdk.ValAddress(addr), addr, nil
+}
+
+// readPassphrase returns the passphrase stored in the given file, without the trailing newline.
+func readPassphrase(path string) (string, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read KEYRING_PASSPHRASE_FILE: %w", err)
+	}
+	return strings.TrimRight(string(b), "\r\n"), nil
+}
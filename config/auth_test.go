@@ -0,0 +1,175 @@
+package config
+
+import (
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/NibiruChain/nibiru/app"
+	"github.com/cosmos/cosmos-sdk/crypto"
+	"github.com/cosmos/cosmos-sdk/crypto/hd"
+	"github.com/cosmos/cosmos-sdk/crypto/keyring"
+	"github.com/cosmos/cosmos-sdk/crypto/keys/secp256k1"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+)
+
+const testMnemonic = "guard cream sadness conduct invite crumble clock pudding hole grit liar hotel maid produce squeeze return argue turtle know drive eight casino maze host"
+
+func TestConfig_FeederAuth(t *testing.T) {
+	_, _, wantAddr := GetAuth(testMnemonic)
+
+	writeFile := func(t *testing.T, name, content string) string {
+		path := filepath.Join(t.TempDir(), name)
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+		return path
+	}
+	newSDKKeyring := func(t *testing.T, backend, passphrase string) string {
+		dir := t.TempDir()
+		// a new file keyring asks for its passphrase twice
+		kb, err := keyring.New(keyringAppName, backend, dir, strings.NewReader(passphrase+"\n"+passphrase+"\n"), app.MakeEncodingConfig().Marshaler)
+		require.NoError(t, err)
+		_, err = kb.NewAccount("feeder", testMnemonic, "", sdk.FullFundraiserPath, hd.Secp256k1)
+		require.NoError(t, err)
+		return dir
+	}
+
+	tests := map[string]KeyringConfig{
+		"test keyring": {Backend: KeyringBackendTest, Dir: newSDKKeyring(t, KeyringBackendTest, ""), KeyName: "feeder"},
+		"file keyring": {
+			Backend:        KeyringBackendFile,
+			Dir:            newSDKKeyring(t, KeyringBackendFile, "12345678"),
+			KeyName:        "feeder",
+			PassphraseFile: writeFile(t, "passphrase", "12345678\n"),
+		},
+	}
+
+	// the same key as the mnemonic, exported
+	kr, _, _ := GetAuth(testMnemonic)
+	privKey := kr.(*privKeyKeyring).privKey
+	tests["armored key"] = KeyringConfig{
+		Backend:        KeyringBackendArmor,
+		ArmoredKeyFile: writeFile(t, "key.armor", crypto.EncryptArmorPrivKey(privKey, "passphrase", "secp256k1")),
+		PassphraseFile: writeFile(t, "passphrase", "passphrase"),
+	}
+	tests["hex key"] = KeyringConfig{Backend: KeyringBackendHex, PrivateKeyHex: hex.EncodeToString(privKey.Bytes())}
+
+	for name, keyringConfig := range tests {
+		t.Run(name, func(t *testing.T) {
+			require.NoError(t, keyringConfig.Validate(""))
+			c := &Config{Keyring: keyringConfig}
+			kb, valAddr, feederAddr, err := c.FeederAuth()
+			require.NoError(t, err)
+			require.Equal(t, wantAddr, feederAddr)
+			require.Equal(t, sdk.ValAddress(wantAddr), valAddr)
+
+			// the key signs
+			record, err := kb.KeyByAddress(feederAddr)
+			require.NoError(t, err)
+			_, pubKey, err := kb.Sign(record.Name, []byte("msg"))
+			require.NoError(t, err)
+			require.Equal(t, privKey.PubKey(), pubKey)
+		})
+	}
+
+	t.Run("wrong passphrase", func(t *testing.T) {
+		c := &Config{Keyring: tests["armored key"]}
+		c.Keyring.PassphraseFile = writeFile(t, "passphrase", "wrong")
+		_, _, _, err := c.FeederAuth()
+		require.ErrorContains(t, err, "failed to decrypt")
+	})
+
+	t.Run("invalid hex key", func(t *testing.T) {
+		c := &Config{Keyring: KeyringConfig{Backend: KeyringBackendHex, PrivateKeyHex: hex.EncodeToString(secp256k1.GenPrivKey().Bytes()[:16])}}
+		_, _, _, err := c.FeederAuth()
+		require.ErrorContains(t, err, "32 bytes")
+	})
+}
+
+func TestKeyringConfig_Validate(t *testing.T) {
+	require.NoError(t, KeyringConfig{Backend: KeyringBackendMnemonic}.Validate(testMnemonic))
+	require.ErrorContains(t, KeyringConfig{Backend: KeyringBackendMnemonic}.Validate(""), "no feeder mnemonic")
+	require.ErrorContains(t, KeyringConfig{Backend: KeyringBackendTest, Dir: "keys"}.Validate(""), "KEYRING_KEY_NAME")
+	require.ErrorContains(t, KeyringConfig{Backend: KeyringBackendFile, Dir: "keys", KeyName: "feeder"}.Validate(""), "KEYRING_PASSPHRASE_FILE")
+	require.ErrorContains(t, KeyringConfig{Backend: KeyringBackendArmor}.Validate(""), "ARMORED_KEY_FILE")
+	require.ErrorContains(t, KeyringConfig{Backend: KeyringBackendHex}.Validate(""), "FEEDER_PRIVATE_KEY")
+	require.ErrorContains(t, KeyringConfig{Backend: KeyringBackendRemote}.Validate(""), "REMOTE_SIGNER_URL")
+	require.ErrorContains(t, KeyringConfig{Backend: KeyringBackendRemote, RemoteSignerURL: "https://signer:8090", RemoteSignerCAFile: "ca.pem"}.Validate(""), "must be set together")
+	require.ErrorContains(t, KeyringConfig{Backend: "os"}.Validate(""), "unsupported keyring backend")
+}
+
+func TestConfig_HDPath(t *testing.T) {
+	os.Setenv("CHAIN_ID", "nibiru-localnet-0")
+	os.Setenv("FEEDER_MNEMONIC", testMnemonic)
+	defer func() {
+		os.Unsetenv("HD_COIN_TYPE")
+		os.Unsetenv("HD_ACCOUNT")
+		os.Unsetenv("HD_ADDRESS_INDEX")
+		os.Unsetenv("BIP39_PASSPHRASE")
+	}()
+
+	// the default path matches GetAuth
+	conf, err := Get()
+	require.NoError(t, err)
+	require.Equal(t, "m/44'/118'/0'/0/0", conf.Keyring.HDPath().String())
+	_, _, defaultAddr, err := conf.FeederAuth()
+	require.NoError(t, err)
+	_, _, wantAddr := GetAuth(testMnemonic)
+	require.Equal(t, wantAddr, defaultAddr)
+
+	os.Setenv("HD_COIN_TYPE", "60")
+	os.Setenv("HD_ACCOUNT", "1")
+	os.Setenv("HD_ADDRESS_INDEX", "2")
+	conf, err = Get()
+	require.NoError(t, err)
+	require.Equal(t, "m/44'/60'/1'/0/2", conf.Keyring.HDPath().String())
+	_, _, pathAddr, err := conf.FeederAuth()
+	require.NoError(t, err)
+	require.NotEqual(t, defaultAddr, pathAddr)
+
+	// the passphrase changes the seed
+	os.Setenv("BIP39_PASSPHRASE", "passphrase")
+	conf, err = Get()
+	require.NoError(t, err)
+	_, _, passphraseAddr, err := conf.FeederAuth()
+	require.NoError(t, err)
+	require.NotEqual(t, pathAddr, passphraseAddr)
+
+	os.Setenv("HD_ACCOUNT", "2147483648")
+	_, err = Get()
+	require.ErrorContains(t, err, "HD_ACCOUNT")
+}
+
+func TestConfig_ValidatorHDPath(t *testing.T) {
+	os.Setenv("CHAIN_ID", "nibiru-localnet-0")
+	os.Setenv("FEEDER_MNEMONIC", testMnemonic)
+	defer func() {
+		os.Unsetenv("VALIDATOR_HD_COIN_TYPE")
+		os.Unsetenv("VALIDATOR_HD_ACCOUNT")
+		os.Unsetenv("VALIDATOR_HD_ADDRESS_INDEX")
+		os.Unsetenv("VALIDATOR_BIP39_PASSPHRASE")
+	}()
+
+	// the default path matches GetAuth
+	conf, err := Get()
+	require.NoError(t, err)
+	require.Equal(t, "m/44'/118'/0'/0/0", conf.ValidatorKey.HDPath().String())
+	require.Empty(t, conf.ValidatorKey.BIP39Passphrase)
+
+	// the validator key is derived independently of the feeder key
+	os.Setenv("VALIDATOR_HD_COIN_TYPE", "60")
+	os.Setenv("VALIDATOR_HD_ACCOUNT", "1")
+	os.Setenv("VALIDATOR_HD_ADDRESS_INDEX", "2")
+	os.Setenv("VALIDATOR_BIP39_PASSPHRASE", "passphrase")
+	conf, err = Get()
+	require.NoError(t, err)
+	require.Equal(t, "m/44'/60'/1'/0/2", conf.ValidatorKey.HDPath().String())
+	require.Equal(t, "passphrase", conf.ValidatorKey.BIP39Passphrase)
+	require.Equal(t, "m/44'/118'/0'/0/0", conf.Keyring.HDPath().String())
+
+	os.Setenv("VALIDATOR_HD_ADDRESS_INDEX", "-1")
+	_, err = Get()
+	require.ErrorContains(t, err, "VALIDATOR_HD_ADDRESS_INDEX")
+}
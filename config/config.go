@@ -1,15 +1,21 @@
 package config
 
 import (
+	"crypto/tls"
+	"crypto/x509"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"os"
+	"strconv"
+	"time"
 
 	"github.com/NibiruChain/nibiru/x/common/asset"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/joho/godotenv"
 
+	"github.com/NibiruChain/pricefeeder/feeder/eventstream"
+	"github.com/NibiruChain/pricefeeder/feeder/priceposter"
 	"github.com/NibiruChain/pricefeeder/feeder/priceprovider/sources"
 	"github.com/NibiruChain/pricefeeder/types"
 )
@@ -17,6 +23,9 @@ import (
 const (
 	defaultGrpcEndpoint      = "localhost:9090"
 	defaultWebsocketEndpoint = "ws://localhost:26657/websocket"
+
+	defaultMissCounterInterval = 1 * time.Minute
+	defaultBalanceInterval     = 5 * time.Minute
 )
 
 var defaultExchangeSymbolsMap = map[string]map[asset.Pair]types.Symbol{
@@ -93,8 +102,36 @@ func Get() (*Config, error) {
 	conf.GRPCEndpoint = os.Getenv("GRPC_ENDPOINT")
 	conf.WebsocketEndpoint = os.Getenv("WEBSOCKET_ENDPOINT")
 	conf.FeederMnemonic = os.Getenv("FEEDER_MNEMONIC")
+	conf.Keyring = KeyringConfig{
+		Backend:        os.Getenv("KEYRING_BACKEND"),
+		Dir:            os.Getenv("KEYRING_DIR"),
+		KeyName:        os.Getenv("KEYRING_KEY_NAME"),
+		PassphraseFile: os.Getenv("KEYRING_PASSPHRASE_FILE"),
+		ArmoredKeyFile: os.Getenv("ARMORED_KEY_FILE"),
+		PrivateKeyHex:  os.Getenv("FEEDER_PRIVATE_KEY"),
+
+		CoinType:        sdk.CoinType,
+		BIP39Passphrase: os.Getenv("BIP39_PASSPHRASE"),
+
+		RemoteSignerURL:      os.Getenv("REMOTE_SIGNER_URL"),
+		RemoteSignerCAFile:   os.Getenv("REMOTE_SIGNER_CA_FILE"),
+		RemoteSignerCertFile: os.Getenv("REMOTE_SIGNER_CERT_FILE"),
+		RemoteSignerKeyFile:  os.Getenv("REMOTE_SIGNER_KEY_FILE"),
+	}
+	conf.ValidatorKey = KeyringConfig{
+		Backend:         KeyringBackendMnemonic,
+		CoinType:        sdk.CoinType,
+		BIP39Passphrase: os.Getenv("VALIDATOR_BIP39_PASSPHRASE"),
+	}
 	conf.EnableTLS = os.Getenv("ENABLE_TLS") == "true"
-	conf.ExchangesToPairToSymbolMap = defaultExchangeSymbolsMap
+	conf.PrevoteStateFile = os.Getenv("PREVOTE_STATE_FILE")
+	conf.DryRunFile = os.Getenv("DRY_RUN_FILE")
+	conf.DryRunSimulate = os.Getenv("DRY_RUN_SIMULATE") == "true"
+	conf.TLSCAFile = os.Getenv("TLS_CA_FILE")
+	conf.TLSCertFile = os.Getenv("TLS_CERT_FILE")
+	conf.TLSKeyFile = os.Getenv("TLS_KEY_FILE")
+	conf.TLSServerName = os.Getenv("TLS_SERVER_NAME")
+	conf.EventSubscription = eventstream.Subscription(os.Getenv("EVENT_SUBSCRIPTION"))
 
 	if conf.GRPCEndpoint == "" {
 		conf.GRPCEndpoint = defaultGrpcEndpoint
@@ -102,33 +139,129 @@ func Get() (*Config, error) {
 	if conf.WebsocketEndpoint == "" {
 		conf.WebsocketEndpoint = defaultWebsocketEndpoint
 	}
+	if conf.EventSubscription == "" {
+		conf.EventSubscription = eventstream.DefaultSubscription
+	}
+	if conf.Keyring.Backend == "" {
+		conf.Keyring.Backend = KeyringBackendMnemonic
+	}
+
+	var err error
+	conf.PriceSourcesFile = os.Getenv("PRICE_SOURCES_FILE")
+	conf.ExchangesToPairToSymbolMap, conf.DataSourceConfigMap, err = ReadPriceSources(conf.PriceSourcesFile)
+	if err != nil {
+		return nil, err
+	}
 
-	overrideExchangeSymbolsMapJson := os.Getenv("EXCHANGE_SYMBOLS_MAP")
-	if overrideExchangeSymbolsMapJson != "" {
-		overrideExchangeSymbolsMap := map[string]map[string]string{}
-		err := json.Unmarshal([]byte(overrideExchangeSymbolsMapJson), &overrideExchangeSymbolsMap)
+	// HD paths of the feeder key and of the validator key derived from their mnemonics
+	for env, field := range map[string]*uint32{
+		"HD_COIN_TYPE":               &conf.Keyring.CoinType,
+		"HD_ACCOUNT":                 &conf.Keyring.Account,
+		"HD_ADDRESS_INDEX":           &conf.Keyring.AddressIndex,
+		"VALIDATOR_HD_COIN_TYPE":     &conf.ValidatorKey.CoinType,
+		"VALIDATOR_HD_ACCOUNT":       &conf.ValidatorKey.Account,
+		"VALIDATOR_HD_ADDRESS_INDEX": &conf.ValidatorKey.AddressIndex,
+	} {
+		if value := os.Getenv(env); value != "" {
+			v, err := strconv.ParseUint(value, 10, 31) // hardened indexes are below 2^31
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse %s: %w", env, err)
+			}
+			*field = uint32(v)
+		}
+	}
+
+	tlsMinVersion := os.Getenv("TLS_MIN_VERSION")
+	if tlsMinVersion != "" {
+		v, ok := tlsVersions[tlsMinVersion]
+		if !ok {
+			return nil, fmt.Errorf("invalid TLS_MIN_VERSION %q, must be one of 1.0, 1.1, 1.2, 1.3", tlsMinVersion)
+		}
+		conf.TLSMinVersion = v
+	}
+
+	// tx fees
+	conf.Fees = priceposter.DefaultFeeConfig()
+	if gasAdjustment := os.Getenv("GAS_ADJUSTMENT"); gasAdjustment != "" {
+		v, err := strconv.ParseFloat(gasAdjustment, 64)
 		if err != nil {
-			return nil, fmt.Errorf("failed to parse EXCHANGE_SYMBOLS_MAP: %w", err)
+			return nil, fmt.Errorf("failed to parse GAS_ADJUSTMENT: %w", err)
 		}
-		for exchange, symbolMap := range overrideExchangeSymbolsMap {
-			conf.ExchangesToPairToSymbolMap[exchange] = map[asset.Pair]types.Symbol{}
-			for nibiAssetPair, tickerSymbol := range symbolMap {
-				conf.ExchangesToPairToSymbolMap[exchange][asset.MustNewPair(nibiAssetPair)] = types.Symbol(tickerSymbol)
-			}
+		conf.Fees.GasAdjustment = v
+	}
+	if gasPrices := os.Getenv("GAS_PRICES"); gasPrices != "" {
+		v, err := sdk.ParseDecCoins(gasPrices)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse GAS_PRICES: %w", err)
+		}
+		conf.Fees.GasPrices = v
+	}
+	conf.Fees.UseNodeMinGasPrices = os.Getenv("USE_NODE_MIN_GAS_PRICES") == "true"
+	if maxFee := os.Getenv("MAX_FEE"); maxFee != "" {
+		v, err := sdk.ParseCoinsNormalized(maxFee)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse MAX_FEE: %w", err)
+		}
+		conf.Fees.MaxFee = v
+	}
+
+	if granter := os.Getenv("FEE_GRANTER"); granter != "" {
+		v, err := sdk.AccAddressFromBech32(granter)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse FEE_GRANTER: %w", err)
+		}
+		conf.Fees.Granter = v
+	}
+
+	// optional limit on how long the chain connection can be down before the feeder stops
+	maxDisconnectedTime := os.Getenv("MAX_DISCONNECTED_TIME")
+	if maxDisconnectedTime != "" {
+		d, err := time.ParseDuration(maxDisconnectedTime)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse MAX_DISCONNECTED_TIME: %w", err)
 		}
+		conf.MaxDisconnectedTime = d
 	}
 
-	// datasource config map
-	datasourceConfigMapJson := os.Getenv("DATASOURCE_CONFIG_MAP")
-	datasourceConfigMap := map[string]json.RawMessage{}
+	// optional active/standby election among feeders of the same validators
+	conf.LeaderElection, err = getLeaderElection()
+	if err != nil {
+		return nil, err
+	}
+
+	// how often the validator's missed votes are checked, zero disables the check
+	conf.MissCounterInterval = defaultMissCounterInterval
+	if missCounterInterval := os.Getenv("MISS_COUNTER_INTERVAL"); missCounterInterval != "" {
+		d, err := time.ParseDuration(missCounterInterval)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse MISS_COUNTER_INTERVAL: %w", err)
+		}
+		conf.MissCounterInterval = d
+	}
 
-	if datasourceConfigMapJson != "" {
-		err := json.Unmarshal([]byte(datasourceConfigMapJson), &datasourceConfigMap)
+	// feeder balance checks, zero disables them
+	conf.BalanceInterval = defaultBalanceInterval
+	if balanceInterval := os.Getenv("BALANCE_CHECK_INTERVAL"); balanceInterval != "" {
+		d, err := time.ParseDuration(balanceInterval)
 		if err != nil {
-			return nil, fmt.Errorf("failed to parse DATASOURCE_CONFIG_MAP: invalid json")
+			return nil, fmt.Errorf("failed to parse BALANCE_CHECK_INTERVAL: %w", err)
 		}
+		conf.BalanceInterval = d
+	}
+	if warning := os.Getenv("BALANCE_WARNING_THRESHOLD"); warning != "" {
+		v, err := sdk.ParseCoinsNormalized(warning)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse BALANCE_WARNING_THRESHOLD: %w", err)
+		}
+		conf.BalanceThresholds.Warning = v
+	}
+	if critical := os.Getenv("BALANCE_CRITICAL_THRESHOLD"); critical != "" {
+		v, err := sdk.ParseCoinsNormalized(critical)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse BALANCE_CRITICAL_THRESHOLD: %w", err)
+		}
+		conf.BalanceThresholds.Critical = v
 	}
-	conf.DataSourceConfigMap = datasourceConfigMap
 
 	// optional validator address (for delegated feeders)
 	valAddrStr := os.Getenv("VALIDATOR_ADDRESS")
@@ -139,26 +272,66 @@ func Get() (*Config, error) {
 		}
 	}
 
+	// optional validators posting prices from the same process, replacing the single validator above
+	if validatorsFile := os.Getenv("VALIDATORS_FILE"); validatorsFile != "" {
+		validators, err := loadValidators(validatorsFile)
+		if err != nil {
+			return nil, err
+		}
+		conf.Validators = validators
+	}
+
 	return conf, conf.Validate()
 }
 
 type Config struct {
 	ExchangesToPairToSymbolMap map[string]map[asset.Pair]types.Symbol
 	DataSourceConfigMap        map[string]json.RawMessage
+	PriceSourcesFile           string // overrides the price sources, and is reloaded on change, disabled if empty
 	GRPCEndpoint               string
 	WebsocketEndpoint          string
+	EventSubscription          eventstream.Subscription
 	FeederMnemonic             string
+	Keyring                    KeyringConfig
 	ChainID                    string
 	ValidatorAddr              *sdk.ValAddress
+	ValidatorKey               KeyringConfig     // HD path and passphrase of the operator key signing the feeder delegation
+	Validators                 []ValidatorConfig // set by VALIDATORS_FILE, see ValidatorConfigs
 	EnableTLS                  bool
+	TLSCAFile                  string // PEM bundle used instead of the system roots
+	TLSCertFile                string // client certificate for mutual TLS
+	TLSKeyFile                 string // client key for mutual TLS
+	TLSServerName              string // overrides the server name used for verification and SNI
+	TLSMinVersion              uint16
+	MaxDisconnectedTime        time.Duration
+	Fees                       priceposter.FeeConfig
+	PrevoteStateFile           string // persists the outstanding prevote across restarts, disabled if empty
+	DryRunFile                 string // records txs to this file instead of broadcasting them, disabled if empty
+	DryRunSimulate             bool   // simulates dry run txs against the node to estimate their fees
+	MissCounterInterval        time.Duration
+	BalanceInterval            time.Duration
+	BalanceThresholds          priceposter.BalanceThresholds
+	LeaderElection             LeaderElectionConfig
+}
+
+var tlsVersions = map[string]uint16{
+	"1.0": tls.VersionTLS10,
+	"1.1": tls.VersionTLS11,
+	"1.2": tls.VersionTLS12,
+	"1.3": tls.VersionTLS13,
 }
 
 func (c *Config) Validate() error {
 	if c.ChainID == "" {
 		return fmt.Errorf("no chain id")
 	}
-	if c.FeederMnemonic == "" {
-		return fmt.Errorf("no feeder mnemonic")
+	for i, v := range c.ValidatorConfigs() {
+		if err := v.Keyring.Validate(v.FeederMnemonic); err != nil {
+			if len(c.Validators) > 0 {
+				return fmt.Errorf("validator %d of VALIDATORS_FILE: %w", i, err)
+			}
+			return err
+		}
 	}
 	if c.WebsocketEndpoint == "" {
 		return fmt.Errorf("no websocket endpoint")
@@ -166,5 +339,65 @@ func (c *Config) Validate() error {
 	if c.GRPCEndpoint == "" {
 		return fmt.Errorf("no grpc endpoint")
 	}
+	if err := c.EventSubscription.Validate(); err != nil {
+		return err
+	}
+	if _, err := c.GRPCTLSConfig(); err != nil {
+		return err
+	}
+	if err := c.Fees.Validate(); err != nil {
+		return err
+	}
+	if c.DryRunSimulate && c.DryRunFile == "" {
+		return fmt.Errorf("DRY_RUN_SIMULATE is set but DRY_RUN_FILE is not")
+	}
+	if err := c.LeaderElection.Validate(); err != nil {
+		return err
+	}
 	return nil
 }
+
+// GRPCTLSConfig returns the TLS configuration used for the gRPC connections,
+// or nil if TLS is disabled.
+func (c *Config) GRPCTLSConfig() (*tls.Config, error) {
+	if !c.EnableTLS {
+		if c.TLSCAFile != "" || c.TLSCertFile != "" || c.TLSKeyFile != "" || c.TLSServerName != "" || c.TLSMinVersion != 0 {
+			return nil, fmt.Errorf("TLS options are set but ENABLE_TLS is not true")
+		}
+		return nil, nil
+	}
+
+	tlsConfig := &tls.Config{
+		InsecureSkipVerify: false,
+		ServerName:         c.TLSServerName,
+		MinVersion:         c.TLSMinVersion,
+	}
+	if tlsConfig.MinVersion == 0 {
+		tlsConfig.MinVersion = tls.VersionTLS12
+	}
+
+	if c.TLSCAFile != "" {
+		pem, err := os.ReadFile(c.TLSCAFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read TLS_CA_FILE: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificates found in TLS_CA_FILE %s", c.TLSCAFile)
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	if (c.TLSCertFile == "") != (c.TLSKeyFile == "") {
+		return nil, fmt.Errorf("TLS_CERT_FILE and TLS_KEY_FILE must be set together")
+	}
+	if c.TLSCertFile != "" {
+		cert, err := tls.LoadX509KeyPair(c.TLSCertFile, c.TLSKeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load TLS client certificate: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	return tlsConfig, nil
+}
@@ -1,10 +1,24 @@
 package config
 
 import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
 	"os"
+	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/NibiruChain/nibiru/app"
+	"github.com/NibiruChain/nibiru/x/common/asset"
+	"github.com/NibiruChain/pricefeeder/feeder/eventstream"
+	"github.com/NibiruChain/pricefeeder/feeder/priceposter"
+	"github.com/NibiruChain/pricefeeder/types"
 	"github.com/stretchr/testify/require"
 )
 
@@ -36,3 +50,298 @@ func TestConfig_Without_EXCHANGE_SYMBOLS_MAP(t *testing.T) {
 	_, err := Get()
 	require.NoError(t, err)
 }
+
+func TestConfig_EVENT_SUBSCRIPTION(t *testing.T) {
+	os.Setenv("CHAIN_ID", "nibiru-localnet-0")
+	os.Setenv("FEEDER_MNEMONIC", "earth wash broom grow recall fitness")
+	defer os.Unsetenv("EVENT_SUBSCRIPTION")
+
+	os.Unsetenv("EVENT_SUBSCRIPTION")
+	conf, err := Get()
+	require.NoError(t, err)
+	require.Equal(t, eventstream.SubscriptionNewBlockHeader, conf.EventSubscription)
+
+	os.Setenv("EVENT_SUBSCRIPTION", "NewBlock")
+	conf, err = Get()
+	require.NoError(t, err)
+	require.Equal(t, eventstream.SubscriptionNewBlock, conf.EventSubscription)
+
+	os.Setenv("EVENT_SUBSCRIPTION", "Tx")
+	_, err = Get()
+	require.ErrorContains(t, err, "unsupported event subscription")
+}
+
+func TestConfig_GRPCTLSConfig(t *testing.T) {
+	certFile, keyFile := writeTestCertificate(t)
+
+	t.Run("disabled", func(t *testing.T) {
+		tlsConfig, err := (&Config{}).GRPCTLSConfig()
+		require.NoError(t, err)
+		require.Nil(t, tlsConfig)
+	})
+
+	t.Run("options without ENABLE_TLS", func(t *testing.T) {
+		_, err := (&Config{TLSServerName: "sentry"}).GRPCTLSConfig()
+		require.ErrorContains(t, err, "ENABLE_TLS")
+	})
+
+	t.Run("system roots", func(t *testing.T) {
+		tlsConfig, err := (&Config{EnableTLS: true}).GRPCTLSConfig()
+		require.NoError(t, err)
+		require.Nil(t, tlsConfig.RootCAs)
+		require.Equal(t, uint16(tls.VersionTLS12), tlsConfig.MinVersion)
+	})
+
+	t.Run("custom CA, client certificate and SNI", func(t *testing.T) {
+		tlsConfig, err := (&Config{
+			EnableTLS:     true,
+			TLSCAFile:     certFile,
+			TLSCertFile:   certFile,
+			TLSKeyFile:    keyFile,
+			TLSServerName: "sentry.internal",
+			TLSMinVersion: tls.VersionTLS13,
+		}).GRPCTLSConfig()
+		require.NoError(t, err)
+		require.NotNil(t, tlsConfig.RootCAs)
+		require.Len(t, tlsConfig.Certificates, 1)
+		require.Equal(t, "sentry.internal", tlsConfig.ServerName)
+		require.Equal(t, uint16(tls.VersionTLS13), tlsConfig.MinVersion)
+	})
+
+	t.Run("cert without key", func(t *testing.T) {
+		_, err := (&Config{EnableTLS: true, TLSCertFile: certFile}).GRPCTLSConfig()
+		require.ErrorContains(t, err, "must be set together")
+	})
+
+	t.Run("invalid CA bundle", func(t *testing.T) {
+		_, err := (&Config{EnableTLS: true, TLSCAFile: keyFile}).GRPCTLSConfig()
+		require.ErrorContains(t, err, "no certificates found")
+	})
+
+	t.Run("invalid TLS_MIN_VERSION", func(t *testing.T) {
+		os.Setenv("CHAIN_ID", "nibiru-localnet-0")
+		os.Setenv("FEEDER_MNEMONIC", "earth wash broom grow recall fitness")
+		os.Setenv("TLS_MIN_VERSION", "1.4")
+		defer os.Unsetenv("TLS_MIN_VERSION")
+		_, err := Get()
+		require.ErrorContains(t, err, "invalid TLS_MIN_VERSION")
+	})
+}
+
+// writeTestCertificate writes a self-signed certificate and its key
+// to a temporary directory and returns their paths.
+func writeTestCertificate(t *testing.T) (certFile, keyFile string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "sentry.internal"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+	certFile, keyFile = filepath.Join(dir, "cert.pem"), filepath.Join(dir, "key.pem")
+	require.NoError(t, os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o600))
+	require.NoError(t, os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer}), 0o600))
+	return certFile, keyFile
+}
+
+func TestConfig_Fees(t *testing.T) {
+	os.Setenv("CHAIN_ID", "nibiru-localnet-0")
+	os.Setenv("FEEDER_MNEMONIC", "earth wash broom grow recall fitness")
+	defer func() {
+		os.Unsetenv("GAS_ADJUSTMENT")
+		os.Unsetenv("GAS_PRICES")
+		os.Unsetenv("USE_NODE_MIN_GAS_PRICES")
+		os.Unsetenv("MAX_FEE")
+	}()
+
+	conf, err := Get()
+	require.NoError(t, err)
+	require.Equal(t, priceposter.DefaultFeeConfig(), conf.Fees)
+
+	os.Setenv("GAS_ADJUSTMENT", "2")
+	os.Setenv("GAS_PRICES", "0.05unibi")
+	os.Setenv("USE_NODE_MIN_GAS_PRICES", "true")
+	os.Setenv("MAX_FEE", "500unibi")
+	conf, err = Get()
+	require.NoError(t, err)
+	require.Equal(t, 2.0, conf.Fees.GasAdjustment)
+	require.Equal(t, "0.050000000000000000unibi", conf.Fees.GasPrices.String())
+	require.True(t, conf.Fees.UseNodeMinGasPrices)
+	require.Equal(t, "500unibi", conf.Fees.MaxFee.String())
+
+	os.Setenv("GAS_ADJUSTMENT", "0.5")
+	_, err = Get()
+	require.ErrorContains(t, err, "gas adjustment")
+}
+
+func TestConfig_DRY_RUN(t *testing.T) {
+	os.Setenv("CHAIN_ID", "nibiru-localnet-0")
+	os.Setenv("FEEDER_MNEMONIC", "earth wash broom grow recall fitness")
+	defer os.Unsetenv("DRY_RUN_FILE")
+	defer os.Unsetenv("DRY_RUN_SIMULATE")
+
+	os.Setenv("DRY_RUN_SIMULATE", "true")
+	_, err := Get()
+	require.ErrorContains(t, err, "DRY_RUN_FILE is not")
+
+	os.Setenv("DRY_RUN_FILE", "dryrun.jsonl")
+	conf, err := Get()
+	require.NoError(t, err)
+	require.Equal(t, "dryrun.jsonl", conf.DryRunFile)
+	require.True(t, conf.DryRunSimulate)
+}
+
+func TestConfig_BalanceThresholds(t *testing.T) {
+	os.Setenv("CHAIN_ID", "nibiru-localnet-0")
+	os.Setenv("FEEDER_MNEMONIC", "earth wash broom grow recall fitness")
+	defer os.Unsetenv("BALANCE_WARNING_THRESHOLD")
+	defer os.Unsetenv("BALANCE_CRITICAL_THRESHOLD")
+
+	os.Setenv("BALANCE_WARNING_THRESHOLD", "100000unibi")
+	os.Setenv("BALANCE_CRITICAL_THRESHOLD", "10000unibi")
+	conf, err := Get()
+	require.NoError(t, err)
+	require.Equal(t, "100000unibi", conf.BalanceThresholds.Warning.String())
+	require.Equal(t, "10000unibi", conf.BalanceThresholds.Critical.String())
+
+	os.Setenv("BALANCE_CRITICAL_THRESHOLD", "unibi")
+	_, err = Get()
+	require.ErrorContains(t, err, "BALANCE_CRITICAL_THRESHOLD")
+}
+
+func TestConfig_FEE_GRANTER(t *testing.T) {
+	os.Setenv("CHAIN_ID", "nibiru-localnet-0")
+	os.Setenv("FEEDER_MNEMONIC", "earth wash broom grow recall fitness")
+	defer os.Unsetenv("FEE_GRANTER")
+
+	os.Setenv("FEE_GRANTER", "nibi1zaavvzxez0elundtn32qnk9lkm8kmcsz44g7xl")
+	conf, err := Get()
+	require.NoError(t, err)
+	require.Equal(t, "nibi1zaavvzxez0elundtn32qnk9lkm8kmcsz44g7xl", conf.Fees.Granter.String())
+
+	os.Setenv("FEE_GRANTER", "nibivaloper1d7zygazerfwx4l362tnpcp0ramzm97xvv9ryxr")
+	_, err = Get()
+	require.ErrorContains(t, err, "FEE_GRANTER")
+}
+
+func TestConfig_VALIDATORS_FILE(t *testing.T) {
+	os.Setenv("CHAIN_ID", "nibiru-localnet-0")
+	os.Setenv("FEEDER_MNEMONIC", "earth wash broom grow recall fitness")
+	defer os.Unsetenv("VALIDATORS_FILE")
+
+	// without the file, the single validator of the env
+	os.Unsetenv("VALIDATORS_FILE")
+	conf, err := Get()
+	require.NoError(t, err)
+	require.Len(t, conf.ValidatorConfigs(), 1)
+	require.Equal(t, conf.FeederMnemonic, conf.ValidatorConfigs()[0].FeederMnemonic)
+
+	path := filepath.Join(t.TempDir(), "validators.json")
+	require.NoError(t, os.WriteFile(path, []byte(`[
+		{"validator_address": "nibivaloper1d7zygazerfwx4l362tnpcp0ramzm97xvv9ryxr", "feeder_mnemonic": "earth wash broom grow recall fitness", "prevote_state_file": "val1.json"},
+		{"keyring": {"backend": "hex", "private_key_hex": "0123"}, "prevote_state_file": "val2.json"}
+	]`), 0o600))
+	os.Setenv("VALIDATORS_FILE", path)
+	conf, err = Get()
+	require.NoError(t, err)
+	validators := conf.ValidatorConfigs()
+	require.Len(t, validators, 2)
+	require.Equal(t, "nibivaloper1d7zygazerfwx4l362tnpcp0ramzm97xvv9ryxr", validators[0].ValidatorAddr.String())
+	require.Equal(t, KeyringBackendMnemonic, validators[0].Keyring.Backend)
+	require.Equal(t, "m/44'/118'/0'/0/0", validators[0].Keyring.HDPath().String())
+	require.Equal(t, "val1.json", validators[0].PrevoteStateFile)
+	require.Nil(t, validators[1].ValidatorAddr)
+	require.Equal(t, KeyringBackendHex, validators[1].Keyring.Backend)
+
+	require.NoError(t, os.WriteFile(path, []byte(`[{"keyring": {"backend": "hex"}}]`), 0o600))
+	_, err = Get()
+	require.ErrorContains(t, err, "validator 0 of VALIDATORS_FILE")
+
+	require.NoError(t, os.WriteFile(path, []byte(`[]`), 0o600))
+	_, err = Get()
+	require.ErrorContains(t, err, "no validators")
+}
+
+func TestConfig_PRICE_SOURCES_FILE(t *testing.T) {
+	os.Setenv("CHAIN_ID", "nibiru-localnet-0")
+	os.Setenv("FEEDER_MNEMONIC", "earth wash broom grow recall fitness")
+	os.Setenv("EXCHANGE_SYMBOLS_MAP", `{"bitfinex": {"ubtc:unusd": "tBTCUSD"}, "binance": {"ubtc:unusd": "BTCUSD"}}`)
+	os.Setenv("DATASOURCE_CONFIG_MAP", `{"coingecko": {"api_key": "env"}}`)
+	defer os.Unsetenv("EXCHANGE_SYMBOLS_MAP")
+	defer os.Unsetenv("DATASOURCE_CONFIG_MAP")
+	defer os.Unsetenv("PRICE_SOURCES_FILE")
+
+	// the file overrides the env per exchange
+	path := filepath.Join(t.TempDir(), "sources.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{
+		"exchange_symbols_map": {"binance": {"ubtc:unusd": "BTCUSDT"}},
+		"datasource_config_map": {"coingecko": {"api_key": "file"}}
+	}`), 0o600))
+	os.Setenv("PRICE_SOURCES_FILE", path)
+	conf, err := Get()
+	require.NoError(t, err)
+	require.Equal(t, path, conf.PriceSourcesFile)
+	btc := asset.MustNewPair("ubtc:unusd")
+	require.Equal(t, types.Symbol("tBTCUSD"), conf.ExchangesToPairToSymbolMap["bitfinex"][btc])
+	require.Equal(t, types.Symbol("BTCUSDT"), conf.ExchangesToPairToSymbolMap["binance"][btc])
+	require.JSONEq(t, `{"api_key": "file"}`, string(conf.DataSourceConfigMap["coingecko"]))
+
+	require.NoError(t, os.WriteFile(path, []byte(`{"exchange_symbols_map": {"binance": {"ubtc": "BTCUSDT"}}}`), 0o600))
+	_, _, err = ReadPriceSources(path)
+	require.ErrorContains(t, err, "invalid PRICE_SOURCES_FILE")
+
+	require.NoError(t, os.WriteFile(path, []byte(`{`), 0o600))
+	_, _, err = ReadPriceSources(path)
+	require.ErrorContains(t, err, "failed to parse PRICE_SOURCES_FILE")
+}
+
+func TestConfig_LEADER_ELECTION(t *testing.T) {
+	os.Setenv("CHAIN_ID", "nibiru-localnet-0")
+	os.Setenv("FEEDER_MNEMONIC", "earth wash broom grow recall fitness")
+	defer os.Unsetenv("LEADER_ELECTION")
+	defer os.Unsetenv("LEADER_ID")
+	defer os.Unsetenv("LEADER_LOCK_FILE")
+	defer os.Unsetenv("LEADER_LEASE_URL")
+	defer os.Unsetenv("LEADER_LEASE_TTL")
+
+	conf, err := Get()
+	require.NoError(t, err)
+	require.Empty(t, conf.LeaderElection.Backend)
+	hostname, err := os.Hostname()
+	require.NoError(t, err)
+	require.Equal(t, hostname, conf.LeaderElection.ID)
+
+	os.Setenv("LEADER_ELECTION", "file")
+	_, err = Get()
+	require.ErrorContains(t, err, "LEADER_LOCK_FILE is not set")
+	os.Setenv("LEADER_LOCK_FILE", "/shared/leader.lock")
+	_, err = Get()
+	require.NoError(t, err)
+
+	os.Setenv("LEADER_ELECTION", "lease")
+	os.Setenv("LEADER_LEASE_URL", "http://leases:8091/v1/leases/validator")
+	os.Setenv("LEADER_LEASE_TTL", "6s")
+	os.Setenv("LEADER_ID", "feeder-a")
+	conf, err = Get()
+	require.NoError(t, err)
+	require.Equal(t, LeaderElectionConfig{
+		Backend:  LeaderElectionLease,
+		ID:       "feeder-a",
+		LockFile: "/shared/leader.lock",
+		LeaseURL: "http://leases:8091/v1/leases/validator",
+		LeaseTTL: 6 * time.Second,
+	}, conf.LeaderElection)
+
+	os.Setenv("LEADER_ELECTION", "etcd")
+	_, err = Get()
+	require.ErrorContains(t, err, "invalid LEADER_ELECTION")
+}
@@ -29,17 +29,26 @@ type privKeyKeyring struct {
 	MigratorNull
 }
 
+// GetAuth derives the key of the mnemonic at the default path m/44'/118'/0'/0/0, without BIP39 passphrase.
 func GetAuth(mnemonic string) (keyring.Keyring, sdk.ValAddress, sdk.AccAddress) {
-	seed := bip39.NewSeed(mnemonic, "")
+	kr, valAddr, addr, err := GetAuthWithHDPath(mnemonic, "", *hd.NewFundraiserParams(0, sdk.CoinType, 0))
+	if err != nil {
+		panic(err)
+	}
+	return kr, valAddr, addr
+}
+
+// GetAuthWithHDPath derives the key of the mnemonic at the given BIP44 path, using the given BIP39 passphrase.
+func GetAuthWithHDPath(mnemonic, bip39Passphrase string, path hd.BIP44Params) (keyring.Keyring, sdk.ValAddress, sdk.AccAddress, error) {
+	seed := bip39.NewSeed(mnemonic, bip39Passphrase)
 	master, ch := hd.ComputeMastersFromSeed(seed)
-	path := "m/44'/118'/0'/0/0"
 
-	priv, err := hd.DerivePrivateKeyForPath(master, ch, path)
+	priv, err := hd.DerivePrivateKeyForPath(master, ch, path.String())
 	if err != nil {
-		panic(err)
+		return nil, nil, nil, fmt.Errorf("failed to derive key at %s: %w", path.String(), err)
 	}
 	kr := newPrivKeyKeyring(hex.EncodeToString(priv))
-	return kr, sdk.ValAddress(kr.addr), kr.addr
+	return kr, sdk.ValAddress(kr.addr), kr.addr, nil
 }
 
 func newPrivKeyKeyring(hexKey string) *privKeyKeyring {
@@ -0,0 +1,75 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"time"
+)
+
+// Leader election backends, see LeaderElectionConfig.
+const (
+	LeaderElectionFile  = "file"
+	LeaderElectionLease = "lease"
+)
+
+// defaultLeaderLeaseTTL is the default LEADER_LEASE_TTL, well below the length of a voting period
+// so that a standby feeder takes over within one.
+const defaultLeaderLeaseTTL = 10 * time.Second
+
+// LeaderElectionConfig configures the election of the feeder posting prices among several
+// feeders running for the same validators. An empty backend disables the election.
+type LeaderElectionConfig struct {
+	Backend  string
+	ID       string        // identifies this feeder to the others, the hostname by default
+	LockFile string        // lock file on a volume shared by the feeders, for the file backend
+	LeaseURL string        // URL of the lease on a lease server, for the lease backend
+	LeaseTTL time.Duration // how long the lease runs without renewal, for the lease backend
+}
+
+// getLeaderElection reads the leader election settings from the env.
+func getLeaderElection() (LeaderElectionConfig, error) {
+	c := LeaderElectionConfig{
+		Backend:  os.Getenv("LEADER_ELECTION"),
+		ID:       os.Getenv("LEADER_ID"),
+		LockFile: os.Getenv("LEADER_LOCK_FILE"),
+		LeaseURL: os.Getenv("LEADER_LEASE_URL"),
+		LeaseTTL: defaultLeaderLeaseTTL,
+	}
+	if ttl := os.Getenv("LEADER_LEASE_TTL"); ttl != "" {
+		d, err := time.ParseDuration(ttl)
+		if err != nil {
+			return c, fmt.Errorf("failed to parse LEADER_LEASE_TTL: %w", err)
+		}
+		c.LeaseTTL = d
+	}
+	if c.ID == "" {
+		hostname, err := os.Hostname()
+		if err != nil {
+			return c, fmt.Errorf("LEADER_ID is not set and the hostname is unknown: %w", err)
+		}
+		c.ID = hostname
+	}
+	return c, nil
+}
+
+// Validate checks that the settings of the backend are set.
+func (c LeaderElectionConfig) Validate() error {
+	switch c.Backend {
+	case "":
+		return nil
+	case LeaderElectionFile:
+		if c.LockFile == "" {
+			return fmt.Errorf("LEADER_ELECTION is %s but LEADER_LOCK_FILE is not set", c.Backend)
+		}
+	case LeaderElectionLease:
+		if c.LeaseURL == "" {
+			return fmt.Errorf("LEADER_ELECTION is %s but LEADER_LEASE_URL is not set", c.Backend)
+		}
+		if c.LeaseTTL <= 0 {
+			return fmt.Errorf("LEADER_LEASE_TTL must be positive")
+		}
+	default:
+		return fmt.Errorf("invalid LEADER_ELECTION %q, must be %s or %s", c.Backend, LeaderElectionFile, LeaderElectionLease)
+	}
+	return nil
+}
@@ -0,0 +1,79 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/NibiruChain/nibiru/x/common/asset"
+	"github.com/NibiruChain/pricefeeder/types"
+)
+
+// priceSourcesFile is the content of the PRICE_SOURCES_FILE. Its maps have the format
+// of EXCHANGE_SYMBOLS_MAP and DATASOURCE_CONFIG_MAP, and override them per exchange.
+type priceSourcesFile struct {
+	ExchangeSymbolsMap  map[string]map[string]string `json:"exchange_symbols_map"`
+	DatasourceConfigMap map[string]json.RawMessage   `json:"datasource_config_map"`
+}
+
+// ReadPriceSources returns the symbols and the configs of the exchanges prices are fetched from.
+// The default symbols are overridden per exchange by EXCHANGE_SYMBOLS_MAP, and then by the given
+// PRICE_SOURCES_FILE if not empty. Unlike the env vars, the file can be read again while the feeder runs.
+func ReadPriceSources(file string) (map[string]map[asset.Pair]types.Symbol, map[string]json.RawMessage, error) {
+	exchangeSymbolsMap := make(map[string]map[asset.Pair]types.Symbol, len(defaultExchangeSymbolsMap))
+	for exchange, symbolMap := range defaultExchangeSymbolsMap {
+		exchangeSymbolsMap[exchange] = symbolMap
+	}
+
+	if overrideExchangeSymbolsMapJson := os.Getenv("EXCHANGE_SYMBOLS_MAP"); overrideExchangeSymbolsMapJson != "" {
+		overrideExchangeSymbolsMap := map[string]map[string]string{}
+		if err := json.Unmarshal([]byte(overrideExchangeSymbolsMapJson), &overrideExchangeSymbolsMap); err != nil {
+			return nil, nil, fmt.Errorf("failed to parse EXCHANGE_SYMBOLS_MAP: %w", err)
+		}
+		if err := overrideExchangeSymbols(exchangeSymbolsMap, overrideExchangeSymbolsMap); err != nil {
+			return nil, nil, fmt.Errorf("invalid EXCHANGE_SYMBOLS_MAP: %w", err)
+		}
+	}
+
+	datasourceConfigMap := map[string]json.RawMessage{}
+	if datasourceConfigMapJson := os.Getenv("DATASOURCE_CONFIG_MAP"); datasourceConfigMapJson != "" {
+		if err := json.Unmarshal([]byte(datasourceConfigMapJson), &datasourceConfigMap); err != nil {
+			return nil, nil, fmt.Errorf("failed to parse DATASOURCE_CONFIG_MAP: invalid json")
+		}
+	}
+
+	if file == "" {
+		return exchangeSymbolsMap, datasourceConfigMap, nil
+	}
+	b, err := os.ReadFile(file)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read PRICE_SOURCES_FILE: %w", err)
+	}
+	var sources priceSourcesFile
+	if err := json.Unmarshal(b, &sources); err != nil {
+		return nil, nil, fmt.Errorf("failed to parse PRICE_SOURCES_FILE: %w", err)
+	}
+	if err := overrideExchangeSymbols(exchangeSymbolsMap, sources.ExchangeSymbolsMap); err != nil {
+		return nil, nil, fmt.Errorf("invalid PRICE_SOURCES_FILE: %w", err)
+	}
+	for source, config := range sources.DatasourceConfigMap {
+		datasourceConfigMap[source] = config
+	}
+	return exchangeSymbolsMap, datasourceConfigMap, nil
+}
+
+// overrideExchangeSymbols replaces the symbols of every exchange of overrides.
+func overrideExchangeSymbols(exchangeSymbolsMap map[string]map[asset.Pair]types.Symbol, overrides map[string]map[string]string) error {
+	for exchange, symbolMap := range overrides {
+		pairToSymbol := make(map[asset.Pair]types.Symbol, len(symbolMap))
+		for nibiAssetPair, tickerSymbol := range symbolMap {
+			pair, err := asset.TryNewPair(nibiAssetPair)
+			if err != nil {
+				return fmt.Errorf("exchange %s: %w", exchange, err)
+			}
+			pairToSymbol[pair] = types.Symbol(tickerSymbol)
+		}
+		exchangeSymbolsMap[exchange] = pairToSymbol
+	}
+	return nil
+}
@@ -0,0 +1,84 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/cosmos/cosmos-sdk/crypto/keyring"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// ValidatorConfig configures the feeder of a single validator.
+type ValidatorConfig struct {
+	ValidatorAddr    *sdk.ValAddress // validator the feeder posts prices for, the one of the feeder key if nil
+	FeederMnemonic   string
+	Keyring          KeyringConfig
+	PrevoteStateFile string // persists the outstanding prevote across restarts, disabled if empty
+}
+
+// FeederAuth loads the feeder key of the validator, see Config.FeederAuth.
+func (v ValidatorConfig) FeederAuth() (keyring.Keyring, sdk.ValAddress, sdk.AccAddress, error) {
+	return v.Keyring.auth(v.FeederMnemonic)
+}
+
+// validatorEntry is an entry of the VALIDATORS_FILE.
+type validatorEntry struct {
+	ValidatorAddress string        `json:"validator_address"`
+	FeederMnemonic   string        `json:"feeder_mnemonic"`
+	Keyring          KeyringConfig `json:"keyring"`
+	PrevoteStateFile string        `json:"prevote_state_file"`
+}
+
+// loadValidators reads the validators of the VALIDATORS_FILE, a JSON array of validator entries.
+func loadValidators(path string) ([]ValidatorConfig, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read VALIDATORS_FILE: %w", err)
+	}
+	var entries []json.RawMessage
+	if err := json.Unmarshal(b, &entries); err != nil {
+		return nil, fmt.Errorf("failed to parse VALIDATORS_FILE: %w", err)
+	}
+	if len(entries) == 0 {
+		return nil, fmt.Errorf("VALIDATORS_FILE has no validators")
+	}
+
+	validators := make([]ValidatorConfig, len(entries))
+	for i, raw := range entries {
+		// fields missing from the entry keep their defaults
+		entry := validatorEntry{Keyring: KeyringConfig{Backend: KeyringBackendMnemonic, CoinType: sdk.CoinType}}
+		if err := json.Unmarshal(raw, &entry); err != nil {
+			return nil, fmt.Errorf("failed to parse validator %d of VALIDATORS_FILE: %w", i, err)
+		}
+
+		validators[i] = ValidatorConfig{
+			FeederMnemonic:   entry.FeederMnemonic,
+			Keyring:          entry.Keyring,
+			PrevoteStateFile: entry.PrevoteStateFile,
+		}
+		if entry.ValidatorAddress != "" {
+			valAddr, err := sdk.ValAddressFromBech32(entry.ValidatorAddress)
+			if err != nil {
+				return nil, fmt.Errorf("invalid validator_address of validator %d of VALIDATORS_FILE: %w", i, err)
+			}
+			validators[i].ValidatorAddr = &valAddr
+		}
+	}
+	return validators, nil
+}
+
+// ValidatorConfigs returns the validators to post prices for: the ones of the VALIDATORS_FILE if set,
+// otherwise the single validator configured by the FEEDER_MNEMONIC, KEYRING_*, VALIDATOR_ADDRESS
+// and PREVOTE_STATE_FILE env vars.
+func (c *Config) ValidatorConfigs() []ValidatorConfig {
+	if len(c.Validators) > 0 {
+		return c.Validators
+	}
+	return []ValidatorConfig{{
+		ValidatorAddr:    c.ValidatorAddr,
+		FeederMnemonic:   c.FeederMnemonic,
+		Keyring:          c.Keyring,
+		PrevoteStateFile: c.PrevoteStateFile,
+	}}
+}
@@ -0,0 +1,58 @@
+package eventstream
+
+import (
+	"time"
+
+	"github.com/NibiruChain/pricefeeder/types"
+)
+
+// blockTimeWindow is the number of recent blocks used to estimate the block time.
+const blockTimeWindow = 20
+
+// blockTimeEstimator keeps track of the most recent block headers
+// and estimates the average time between blocks.
+type blockTimeEstimator struct {
+	headers []types.BlockHeader
+}
+
+// observe records a new block header, discarding the oldest one once the window is full.
+// Headers which do not move the chain forward are ignored.
+func (b *blockTimeEstimator) observe(header types.BlockHeader) {
+	if n := len(b.headers); n != 0 && header.Height <= b.headers[n-1].Height {
+		return
+	}
+	b.headers = append(b.headers, header)
+	if len(b.headers) > blockTimeWindow {
+		b.headers = b.headers[len(b.headers)-blockTimeWindow:]
+	}
+}
+
+// average returns the average block time across the observed window.
+// Gaps in heights, caused for example by reconnections, are accounted for.
+// It returns false if there are not enough headers to estimate it.
+func (b *blockTimeEstimator) average() (time.Duration, bool) {
+	if len(b.headers) < 2 {
+		return 0, false
+	}
+	first, last := b.headers[0], b.headers[len(b.headers)-1]
+	elapsed := last.Time.Sub(first.Time)
+	if elapsed <= 0 {
+		return 0, false
+	}
+	return elapsed / time.Duration(last.Height-first.Height), true
+}
+
+// votingPeriod builds the types.VotingPeriod which starts
+// right after the block described by the given header.
+func (b *blockTimeEstimator) votingPeriod(header types.BlockHeader, votePeriodBlocks uint64) types.VotingPeriod {
+	vp := types.VotingPeriod{
+		Height:    header.Height + 1,
+		EndHeight: header.Height + votePeriodBlocks,
+		BlockTime: header.Time,
+		ChainID:   header.ChainID,
+	}
+	if avg, ok := b.average(); ok {
+		vp.Deadline = header.Time.Add(time.Duration(votePeriodBlocks) * avg)
+	}
+	return vp
+}
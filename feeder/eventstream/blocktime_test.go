@@ -0,0 +1,64 @@
+package eventstream
+
+import (
+	"testing"
+	"time"
+
+	"github.com/NibiruChain/pricefeeder/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBlockTimeEstimator(t *testing.T) {
+	start := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	header := func(height uint64, offset time.Duration) types.BlockHeader {
+		return types.BlockHeader{ChainID: "nibiru-localnet-0", Height: height, Time: start.Add(offset)}
+	}
+
+	t.Run("not enough blocks", func(t *testing.T) {
+		b := new(blockTimeEstimator)
+		b.observe(header(9, 0))
+
+		vp := b.votingPeriod(header(9, 0), 10)
+		require.Equal(t, types.VotingPeriod{
+			Height:    10,
+			EndHeight: 19,
+			BlockTime: start,
+			ChainID:   "nibiru-localnet-0",
+		}, vp)
+		require.Zero(t, vp.TimeLeft(start))
+	})
+
+	t.Run("estimates deadline", func(t *testing.T) {
+		b := new(blockTimeEstimator)
+		for i := uint64(0); i < 5; i++ {
+			b.observe(header(5+i, time.Duration(i)*2*time.Second))
+		}
+		avg, ok := b.average()
+		require.True(t, ok)
+		require.Equal(t, 2*time.Second, avg)
+
+		vp := b.votingPeriod(header(9, 8*time.Second), 10)
+		require.Equal(t, start.Add(28*time.Second), vp.Deadline)
+		require.Equal(t, 20*time.Second, vp.TimeLeft(start.Add(8*time.Second)))
+		require.Zero(t, vp.TimeLeft(start.Add(time.Minute)))
+	})
+
+	t.Run("height gaps and stale headers", func(t *testing.T) {
+		b := new(blockTimeEstimator)
+		b.observe(header(1, 0))
+		b.observe(header(5, 4*time.Second))
+		b.observe(header(3, 10*time.Second)) // ignored
+		avg, ok := b.average()
+		require.True(t, ok)
+		require.Equal(t, time.Second, avg)
+	})
+
+	t.Run("window is bounded", func(t *testing.T) {
+		b := new(blockTimeEstimator)
+		for i := uint64(1); i <= 2*blockTimeWindow; i++ {
+			b.observe(header(i, time.Duration(i)*time.Second))
+		}
+		require.Len(t, b.headers, blockTimeWindow)
+		require.Equal(t, uint64(blockTimeWindow+1), b.headers[0].Height)
+	})
+}
@@ -21,42 +21,28 @@ var _ types.EventStream = (*Stream)(nil)
 // Interface for WebSocket connection that provides message channels
 type wsI interface {
 	message() <-chan []byte
+	connectionStateChanged() <-chan types.ConnectionState
 	close()
 }
 
 // Dial opens two connections to the blockchain:
-// 1. WebSocket for real-time event subscription (new blocks)
+// 1. WebSocket for real-time event subscription (new blocks or block headers)
 // 2. gRPC for querying oracle parameters
 // Returns a stream that manages both connections
-func Dial(tendermintRPCEndpoint string, grpcEndpoint string, enableTLS bool, logger zerolog.Logger) *Stream {
-	var transportDialOpt grpc.DialOption
-
-	if enableTLS {
-		transportDialOpt = grpc.WithTransportCredentials(
-			credentials.NewTLS(
-				&tls.Config{
-					InsecureSkipVerify: false,
-				},
-			),
-		)
-	} else {
-		transportDialOpt = grpc.WithTransportCredentials(
-			insecure.NewCredentials(),
-		)
+// A nil tlsConfig dials the gRPC endpoint without transport security.
+func Dial(tendermintRPCEndpoint string, grpcEndpoint string, subscription Subscription, tlsConfig *tls.Config, logger zerolog.Logger) *Stream {
+	transportDialOpt := grpc.WithTransportCredentials(insecure.NewCredentials())
+	if tlsConfig != nil {
+		transportDialOpt = grpc.WithTransportCredentials(credentials.NewTLS(tlsConfig))
 	}
 
 	conn, err := grpc.Dial(grpcEndpoint, transportDialOpt)
-	if err != nil {
-		panic(err)
-	}
-
 	if err != nil {
 		panic(err)
 	}
 	oracleClient := oracletypes.NewQueryClient(conn)
 
-	const newBlockSubscribe = `{"jsonrpc":"2.0","method":"subscribe","id":0,"params":{"query":"tm.event='NewBlock'"}}`
-	ws := NewWebsocket(tendermintRPCEndpoint, []byte(newBlockSubscribe), logger)
+	ws := NewWebsocket(tendermintRPCEndpoint, subscription.subscribeMsg(), logger)
 	return newStream(ws, oracleClient, logger)
 }
 
@@ -68,6 +54,7 @@ func newStream(ws wsI, oracle oracletypes.QueryClient, logger zerolog.Logger) *S
 		votingPeriodChannel: make(chan types.VotingPeriod),
 		paramsChannel:       make(chan types.Params, 1),
 		params:              new(atomic.Pointer[types.Params]),
+		connectionState:     ws.connectionStateChanged(),
 	}
 
 	stream.waitGroup.Add(2)
@@ -87,6 +74,7 @@ type Stream struct {
 	votingPeriodChannel chan types.VotingPeriod
 	paramsChannel       chan types.Params
 	params              *atomic.Pointer[types.Params]
+	connectionState     <-chan types.ConnectionState
 }
 
 // votingPeriodStartedLoop monitors new blocks and detects the start of voting periods.
@@ -98,21 +86,24 @@ func (s *Stream) votingPeriodStartedLoop(ws wsI, logger zerolog.Logger) {
 		ws.close()
 	}()
 
+	blockTimes := new(blockTimeEstimator)
 	for {
 		select {
 		case <-s.stopSignal:
 			return
 		case msg := <-ws.message():
 			logger.Debug().Bytes("payload", msg).Msg("received message from websocket")
-			blockHeight, err := types.GetBlockHeight(msg)
+			header, err := types.GetBlockHeader(msg)
 			if err != nil {
 				logger.Err(err).Msg("could not obtain block height")
 				break
 			}
+			blockHeight := header.Height
 			if blockHeight <= 0 {
 				logger.Err(err).Uint64("block-height", blockHeight).Msg("invalid block height")
 				break
 			}
+			blockTimes.observe(header)
 			p := s.params.Load()
 			if p == nil {
 				break
@@ -125,7 +116,7 @@ func (s *Stream) votingPeriodStartedLoop(ws wsI, logger zerolog.Logger) {
 			select {
 			case <-s.stopSignal:
 				logger.Warn().Uint64("height", blockHeight+1).Msg("dropped voting period signal")
-			case s.votingPeriodChannel <- types.VotingPeriod{Height: blockHeight + 1}:
+			case s.votingPeriodChannel <- blockTimes.votingPeriod(header, p.VotePeriodBlocks):
 				logger.Debug().Msg("signaled new voting period")
 			}
 		}
@@ -197,3 +188,8 @@ func (s *Stream) ParamsUpdate() <-chan types.Params {
 func (s *Stream) VotingPeriodStarted() <-chan types.VotingPeriod {
 	return s.votingPeriodChannel
 }
+
+// ConnectionStateChanged returns a channel that signals transitions of the websocket connection.
+func (s *Stream) ConnectionStateChanged() <-chan types.ConnectionState {
+	return s.connectionState
+}
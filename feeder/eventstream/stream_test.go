@@ -51,11 +51,11 @@ func (s *IntegrationTestSuite) SetupSuite() {
 	u.Path = "/websocket"
 
 	s.logs = new(bytes.Buffer)
-	enableTLS := false
 	s.eventStream = Dial(
 		u.String(),
 		grpcEndpoint,
-		enableTLS,
+		DefaultSubscription,
+		nil,
 		zerolog.New(s.logs))
 
 	conn, err := grpc.Dial(grpcEndpoint, grpc.WithTransportCredentials(insecure.NewCredentials()))
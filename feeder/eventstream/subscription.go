@@ -0,0 +1,33 @@
+package eventstream
+
+import "fmt"
+
+// Subscription is the CometBFT event the stream subscribes to in order to track new blocks.
+type Subscription string
+
+const (
+	// SubscriptionNewBlock subscribes to full blocks, which ships every
+	// transaction of every block over the websocket.
+	SubscriptionNewBlock Subscription = "NewBlock"
+	// SubscriptionNewBlockHeader subscribes to block headers only,
+	// making bandwidth usage independent of the block size.
+	SubscriptionNewBlockHeader Subscription = "NewBlockHeader"
+)
+
+// DefaultSubscription is the Subscription used when none is configured.
+const DefaultSubscription = SubscriptionNewBlockHeader
+
+// Validate returns an error if the Subscription is not supported.
+func (s Subscription) Validate() error {
+	switch s {
+	case SubscriptionNewBlock, SubscriptionNewBlockHeader:
+		return nil
+	default:
+		return fmt.Errorf("unsupported event subscription %q", string(s))
+	}
+}
+
+// subscribeMsg returns the JSON-RPC message which subscribes the websocket to the event.
+func (s Subscription) subscribeMsg() []byte {
+	return []byte(fmt.Sprintf(`{"jsonrpc":"2.0","method":"subscribe","id":0,"params":{"query":"tm.event='%s'"}}`, s))
+}
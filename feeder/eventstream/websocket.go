@@ -1,21 +1,39 @@
 package eventstream
 
 import (
+	"math/rand"
+	"sync"
 	"sync/atomic"
 	"time"
 
+	"github.com/NibiruChain/pricefeeder/metrics"
+	"github.com/NibiruChain/pricefeeder/types"
 	"github.com/gorilla/websocket"
 	"github.com/rs/zerolog"
 )
 
+var (
+	// ReconnectBaseDelay is the wait time before the first reconnection attempt.
+	ReconnectBaseDelay = 1 * time.Second
+	// ReconnectMaxDelay caps the exponential backoff between reconnection attempts.
+	ReconnectMaxDelay = 30 * time.Second
+)
+
+// stateChangeBufferSize is how many connection state transitions
+// are buffered before new ones are dropped.
+const stateChangeBufferSize = 16
+
 type dianFn func() (*websocket.Conn, error)
 
 type ws struct {
 	logger           zerolog.Logger
+	url              string
 	stopSignal       chan struct{} // external signal to stop the ws
 	done             chan struct{} // internal signal to wait for the ws to execute its shutdown operations
 	read             chan []byte
+	stateChange      chan types.ConnectionState
 	dial             dianFn
+	connectionMutex  sync.Mutex
 	connection       *websocket.Conn
 	connectionClosed *atomic.Bool
 }
@@ -31,9 +49,11 @@ func NewWebsocket(url string, onOpenMsg []byte, logger zerolog.Logger) *ws {
 
 	ws := &ws{
 		logger:           logger.With().Str("component", "websocket").Logger(),
+		url:              url,
 		stopSignal:       make(chan struct{}),
 		done:             make(chan struct{}),
 		read:             make(chan []byte),
+		stateChange:      make(chan types.ConnectionState, stateChangeBufferSize),
 		dial:             dialFunction,
 		connection:       nil,
 		connectionClosed: new(atomic.Bool),
@@ -45,25 +65,28 @@ func NewWebsocket(url string, onOpenMsg []byte, logger zerolog.Logger) *ws {
 
 func (w *ws) loop() {
 	defer close(w.done)
+	defer w.setState(types.ConnectionStateDisconnected)
 
-	if w.connection == nil {
-		w.connect()
+	if !w.connect() {
+		return
 	}
 
 	// read messages and also handles reconnection.
 	for {
-		_, bytes, err := w.connection.ReadMessage()
+		_, bytes, err := w.getConnection().ReadMessage()
 		if err != nil {
 			if w.connectionClosed.Load() {
 				// if the connection was closed, then we exit
 				return
 			}
 
-			// otherwise we attempt to reconnect
-			// we don't care if it fails, because if it does on ReadMessage we will receive an error
-			// and then attempt to reconnect again.
+			// otherwise we attempt to reconnect, connect only
+			// gives up if the websocket is being closed.
 			w.logger.Err(err).Msg("disconnected from websocket, attempting to reconnect")
-			w.connect()
+			w.setState(types.ConnectionStateDisconnected)
+			if !w.connect() {
+				return
+			}
 			continue
 		}
 
@@ -78,42 +101,86 @@ func (w *ws) loop() {
 	}
 }
 
-// attemps to dial the websocket using binary exponential backoff
-func (w *ws) connect() {
+// connect attempts to dial the websocket forever using capped exponential backoff
+// with jitter. It returns false only if the websocket was closed in the meantime.
+func (w *ws) connect() bool {
 	w.logger.Debug().Msg("connecting")
+	w.setState(types.ConnectionStateConnecting)
 
-	retries := 0
-	delay := 1 * time.Second
-	for {
+	for attempt := 0; ; attempt++ {
 		connection, err := w.dial()
 		if err == nil {
+			w.connectionMutex.Lock()
 			w.connection = connection
+			w.connectionMutex.Unlock()
+			// close might have been called while dialing
+			if w.connectionClosed.Load() {
+				_ = connection.Close()
+				return false
+			}
 			w.logger.Debug().Msg("connected to websocket")
-			return
+			w.setState(types.ConnectionStateConnected)
+			return true
 		}
-		// if we failed to connect, we wait and try again
-		w.logger.Err(err).Msg("failed to connect to websocket")
-		retries++
-		if retries > 10 {
-			// if we failed to connect more than 10 times, we exit
-			w.logger.Fatal().Msg("failed to connect to websocket")
+
+		delay := backoffDelay(attempt)
+		metrics.ConnectionAttempts.WithLabelValues("websocket", w.url).Inc()
+		w.logger.Err(err).Int("retries", attempt+1).Dur("retry-in", delay).Msg("failed to connect to websocket, retrying")
+		select {
+		case <-w.stopSignal:
+			return false
+		case <-time.After(delay):
 		}
+	}
+}
 
-		w.logger.Debug().Int("retries", retries).Msg("failed to connect to websocket, retrying")
-		time.Sleep(delay)
-		delay *= 2
+// backoffDelay returns the wait time before the given reconnection attempt,
+// which doubles on every attempt up to ReconnectMaxDelay.
+// Half of the delay is randomized to avoid reconnecting in lockstep with other clients.
+func backoffDelay(attempt int) time.Duration {
+	delay := ReconnectMaxDelay
+	if attempt < 32 && ReconnectBaseDelay<<attempt < ReconnectMaxDelay {
+		delay = ReconnectBaseDelay << attempt
 	}
+	half := delay / 2
+	return half + time.Duration(rand.Int63n(int64(half)+1))
+}
+
+// setState reports the connection state to metrics and to the state change channel.
+// Transitions are dropped if nobody is consuming them.
+func (w *ws) setState(state types.ConnectionState) {
+	connected := 0.0
+	if state == types.ConnectionStateConnected {
+		connected = 1
+	}
+	metrics.ConnectionStatus.WithLabelValues("websocket", w.url).Set(connected)
+
+	select {
+	case w.stateChange <- state:
+	default:
+		w.logger.Warn().Stringer("state", state).Msg("dropped connection state change")
+	}
+}
+
+func (w *ws) getConnection() *websocket.Conn {
+	w.connectionMutex.Lock()
+	defer w.connectionMutex.Unlock()
+	return w.connection
 }
 
 func (w *ws) message() <-chan []byte {
 	return w.read
 }
 
+func (w *ws) connectionStateChanged() <-chan types.ConnectionState {
+	return w.stateChange
+}
+
 func (w *ws) close() {
 	close(w.stopSignal)
 	w.connectionClosed.Store(true)
-	if w.connection != nil {
-		if err := w.connection.Close(); err != nil {
+	if connection := w.getConnection(); connection != nil {
+		if err := connection.Close(); err != nil {
 			w.logger.Err(err).Msg("close error")
 		}
 	}
@@ -1,10 +1,12 @@
 package eventstream
 
 import (
+	"io"
 	"os"
 	"testing"
 	"time"
 
+	"github.com/NibiruChain/pricefeeder/types"
 	"github.com/rs/zerolog"
 	"github.com/stretchr/testify/require"
 )
@@ -34,3 +36,28 @@ func TestWebsocketExplicitClose(t *testing.T) {
 		ws.close()
 	})
 }
+
+func TestBackoffDelay(t *testing.T) {
+	for attempt := 0; attempt < 100; attempt++ {
+		expected := ReconnectMaxDelay
+		if attempt < 5 {
+			expected = ReconnectBaseDelay << attempt
+		}
+		delay := backoffDelay(attempt)
+		require.GreaterOrEqual(t, delay, expected/2, "attempt %d", attempt)
+		require.LessOrEqual(t, delay, expected, "attempt %d", attempt)
+	}
+}
+
+func TestWebsocketRetriesUntilClosed(t *testing.T) {
+	defer func(d time.Duration) { ReconnectBaseDelay = d }(ReconnectBaseDelay)
+	ReconnectBaseDelay = 10 * time.Millisecond
+
+	// nothing listens on port 1, so dialing always fails
+	ws := NewWebsocket("ws://127.0.0.1:1/websocket", []byte("test"), zerolog.New(io.Discard))
+	require.Equal(t, types.ConnectionStateConnecting, <-ws.connectionStateChanged())
+
+	<-time.After(100 * time.Millisecond) // several failed attempts
+	require.NotPanics(t, ws.close)
+	require.Equal(t, types.ConnectionStateDisconnected, <-ws.connectionStateChanged())
+}
@@ -1,9 +1,12 @@
 package feeder
 
 import (
-	"fmt"
+	"context"
+	"sync"
 	"time"
 
+	"github.com/NibiruChain/nibiru/x/common/asset"
+	"github.com/NibiruChain/pricefeeder/metrics"
 	"github.com/NibiruChain/pricefeeder/types"
 	"github.com/rs/zerolog"
 )
@@ -11,6 +14,21 @@ import (
 var (
 	// InitTimeout defines how long to wait for initial parameters before failing
 	InitTimeout = 15 * time.Second
+	// MaxDisconnectedTime defines how long the connection to the chain can be down
+	// before the feeder stops itself. Zero keeps the feeder running indefinitely.
+	MaxDisconnectedTime time.Duration = 0
+	// VotingPeriodTimeout bounds the processing of a voting period whose end could not be estimated.
+	VotingPeriodTimeout = 15 * time.Second
+	// PriceGatheringTimeout bounds how long prices are gathered for in a voting period,
+	// the pairs whose price is not known by then are abstained from.
+	// The gathering is also bounded by the estimated end of the voting period.
+	PriceGatheringTimeout = 5 * time.Second
+)
+
+// Phases of a voting period, as reported to metrics.VotingPeriodPhaseDuration.
+const (
+	phaseGatherPrices = "gather_prices"
+	phaseSendPrices   = "send_prices"
 )
 
 // Feeder is the core component that coordinates price fetching and submission.
@@ -24,21 +42,32 @@ type Feeder struct {
 
 	params types.Params // Oracle module parameters
 
-	eventStream   types.EventStream   // Connects to the blockchain and receives events
-	pricePoster   types.PricePoster   // Submits price votes to the blockchain
-	priceProvider types.PriceProvider // Fetches prices from exchanges
+	disconnectedTimer *time.Timer // Fires when the chain connection has been down for too long
+
+	cancelVotingPeriod context.CancelFunc // Cancels the voting period being processed
+	votingPeriodDone   chan struct{}      // Closed once the voting period being processed is done
+
+	eventStream    types.EventStream    // Connects to the blockchain and receives events
+	pricePosters   []types.PricePoster  // Submit price votes to the blockchain, one per validator
+	priceProvider  types.PriceProvider  // Fetches prices from exchanges
+	leaderElection types.LeaderElection // Decides whether this feeder posts prices, nil if it always does
 }
 
 // NewFeeder creates a new price feeder instance with provided dependencies.
-func NewFeeder(eventStream types.EventStream, priceProvider types.PriceProvider, pricePoster types.PricePoster, logger zerolog.Logger) *Feeder {
+// The prices are fetched once per voting period and posted by every price poster,
+// which lets a single process feed prices for several validators.
+// With a leader election, prices are only posted while this feeder is the leader,
+// and are still fetched otherwise so that it's ready to take over. It can be nil for a single feeder.
+func NewFeeder(eventStream types.EventStream, priceProvider types.PriceProvider, pricePosters []types.PricePoster, leaderElection types.LeaderElection, logger zerolog.Logger) *Feeder {
 	f := &Feeder{
-		logger:        logger,
-		stop:          make(chan struct{}),
-		done:          make(chan struct{}),
-		params:        types.Params{},
-		eventStream:   eventStream,
-		pricePoster:   pricePoster,
-		priceProvider: priceProvider,
+		logger:         logger,
+		stop:           make(chan struct{}),
+		done:           make(chan struct{}),
+		params:         types.Params{},
+		eventStream:    eventStream,
+		pricePosters:   pricePosters,
+		priceProvider:  priceProvider,
+		leaderElection: leaderElection,
 	}
 
 	return f
@@ -61,18 +90,29 @@ func (f *Feeder) initParamsOrDie() {
 	}
 }
 
-// loop is the main event processing loop. It handles three types of events:
+// loop is the main event processing loop. It handles four types of events:
 // 1. Stop signals to shutdown the feeder
 // 2. Parameter updates from the blockchain
 // 3. New voting periods that trigger price submissions
+// 4. Connection state changes, stopping the feeder if the chain is unreachable for too long
 func (f *Feeder) loop() {
 	defer f.close()
 
 	for {
+		var disconnectedTimeout <-chan time.Time
+		if f.disconnectedTimer != nil {
+			disconnectedTimeout = f.disconnectedTimer.C
+		}
+
 		select {
 		case <-f.stop:
 			f.logger.Debug().Msg("stop signal received")
 			return
+		case <-disconnectedTimeout:
+			f.logger.Error().Dur("max-disconnected-time", MaxDisconnectedTime).Msg("chain connection down for too long, stopping")
+			return
+		case state := <-f.eventStream.ConnectionStateChanged():
+			f.handleConnectionState(state)
 		case params := <-f.eventStream.ParamsUpdate():
 			f.logger.Info().Interface("changes", params).Msg("params changed")
 			f.handleParamsUpdate(params)
@@ -85,8 +125,20 @@ func (f *Feeder) loop() {
 
 // close properly shuts down all feeder components.
 func (f *Feeder) close() {
+	if f.disconnectedTimer != nil {
+		f.disconnectedTimer.Stop()
+	}
+	if f.cancelVotingPeriod != nil {
+		f.cancelVotingPeriod()
+		<-f.votingPeriodDone
+	}
+	if f.leaderElection != nil {
+		f.leaderElection.Close()
+	}
 	f.eventStream.Close()
-	f.pricePoster.Close()
+	for _, pricePoster := range f.pricePosters {
+		pricePoster.Close()
+	}
 	f.priceProvider.Close()
 	close(f.done)
 }
@@ -96,22 +148,153 @@ func (f *Feeder) handleParamsUpdate(params types.Params) {
 	f.params = params
 }
 
+// handleConnectionState tracks for how long the chain connection has been down,
+// arming the disconnection timeout the first time the connection is lost.
+func (f *Feeder) handleConnectionState(state types.ConnectionState) {
+	f.logger.Info().Stringer("state", state).Msg("connection state changed")
+
+	if state == types.ConnectionStateConnected {
+		if f.disconnectedTimer != nil {
+			f.disconnectedTimer.Stop()
+			f.disconnectedTimer = nil
+		}
+		return
+	}
+	if f.disconnectedTimer == nil && MaxDisconnectedTime > 0 {
+		f.disconnectedTimer = time.NewTimer(MaxDisconnectedTime)
+	}
+}
+
 // handleVotingPeriod is triggered when a new voting period starts.
-// It fetches prices for all configured pairs and submits them to the blockchain.
+// The voting period is processed off the main loop, so that events keep being handled
+// while prices are gathered and posted. The processing of the previous voting period,
+// if still running, is cancelled.
 func (f *Feeder) handleVotingPeriod(vp types.VotingPeriod) {
-	// gather prices
-	prices := make([]types.Price, len(f.params.Pairs))
-	for i, p := range f.params.Pairs {
-		price := f.priceProvider.GetPrice(p)
-		if !price.Valid {
-			f.logger.Err(fmt.Errorf("no valid price")).Str("asset", p.String()).Str("source", price.SourceName)
-			price.Price = 0
+	if f.cancelVotingPeriod != nil {
+		f.cancelVotingPeriod()
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	pairs, previousDone, done := f.params.Pairs, f.votingPeriodDone, make(chan struct{})
+	f.cancelVotingPeriod, f.votingPeriodDone = cancel, done
+
+	go func() {
+		defer close(done)
+		defer cancel()
+		f.processVotingPeriod(ctx, vp, pairs, previousDone)
+	}()
+}
+
+// processVotingPeriod fetches prices for the given pairs and submits them to the blockchain
+// for every validator, concurrently so that a slow validator does not delay the others.
+// Prices are only posted once the previous voting period is done, and not at all
+// if the voting period is cancelled or its deadline, estimated from the block time,
+// is reached before the prices are gathered. Posting is abandoned as well once that happens.
+// A feeder which is not the leader gathers the prices but does not post them.
+func (f *Feeder) processVotingPeriod(ctx context.Context, vp types.VotingPeriod, pairs []asset.Pair, previousDone <-chan struct{}) {
+	logger := f.logger.With().Uint64("voting-period-height", vp.Height).Logger()
+	deadline := vp.Deadline
+	if deadline.IsZero() {
+		deadline = time.Now().Add(VotingPeriodTimeout)
+	}
+	ctx, cancel := context.WithDeadline(ctx, deadline)
+	defer cancel()
+
+	start := time.Now()
+	prices := f.gatherPrices(ctx, pairs, logger)
+	metrics.VotingPeriodPhaseDuration.WithLabelValues(phaseGatherPrices).Observe(time.Since(start).Seconds())
+
+	if previousDone != nil {
+		select {
+		case <-previousDone:
+		case <-ctx.Done():
+		}
+	}
+	if ctx.Err() != nil {
+		logger.Warn().Err(ctx.Err()).Msg("voting period skipped, prices not sent")
+		metrics.SkippedVotingPeriods.Inc()
+		return
+	}
+	if f.leaderElection != nil {
+		if !f.leaderElection.IsLeader() {
+			logger.Info().Msg("standing by, prices not sent")
+			return
 		}
-		prices[i] = price
+		// stop sending as soon as another feeder may have taken over
+		var cancelLeading context.CancelFunc
+		ctx, cancelLeading = f.leaderElection.Leading(ctx)
+		defer cancelLeading()
 	}
 
 	// send prices
-	f.pricePoster.SendPrices(vp, prices)
+	start = time.Now()
+	var wg sync.WaitGroup
+	for _, pricePoster := range f.pricePosters {
+		wg.Add(1)
+		go func(pricePoster types.PricePoster) {
+			defer wg.Done()
+			result, err := pricePoster.SendPrices(ctx, vp, prices)
+			// the price poster reports the outcome itself
+			logger.Debug().Err(err).
+				Str("validator", pricePoster.Whoami().String()).
+				Str("tx-hash", result.TxHash).
+				Str("status", string(result.Status)).
+				Msg("prices sent")
+		}(pricePoster)
+	}
+	wg.Wait()
+	metrics.VotingPeriodPhaseDuration.WithLabelValues(phaseSendPrices).Observe(time.Since(start).Seconds())
+}
+
+// gatherPrices fetches the prices of all the pairs concurrently. The pairs whose price
+// is not fetched within PriceGatheringTimeout, or before the context is done, are abstained from.
+func (f *Feeder) gatherPrices(ctx context.Context, pairs []asset.Pair, logger zerolog.Logger) []types.Price {
+	ctx, cancel := context.WithTimeout(ctx, PriceGatheringTimeout)
+	defer cancel()
+
+	type result struct {
+		index int
+		price types.Price
+	}
+	// buffered so that the late fetches don't block once the results are no longer read
+	results := make(chan result, len(pairs))
+	for i, p := range pairs {
+		go func(i int, p asset.Pair) {
+			results <- result{index: i, price: f.priceProvider.GetPrice(ctx, p)}
+		}(i, p)
+	}
+
+	prices := make([]types.Price, len(pairs))
+	for i, p := range pairs {
+		prices[i] = types.NewAbstainPrice(p, "", types.AbstainTimeout)
+	}
+	for range pairs {
+		select {
+		case r := <-results:
+			prices[r.index] = r.price
+		case <-ctx.Done():
+			logger.Warn().Err(ctx.Err()).Msg("price gathering did not complete, abstaining from the missing pairs")
+			return abstainInvalid(prices, logger)
+		}
+	}
+	return abstainInvalid(prices, logger)
+}
+
+// abstainInvalid turns the invalid prices into abstain votes and reports why they are abstained from.
+func abstainInvalid(prices []types.Price, logger zerolog.Logger) []types.Price {
+	for i, price := range prices {
+		if price.Valid {
+			continue
+		}
+		reason := price.AbstainReason
+		if reason == "" {
+			reason = types.AbstainUnknown
+		}
+		logger.Warn().Str("asset", price.Pair.String()).Str("source", price.SourceName).Str("reason", string(reason)).Msg("no valid price, abstaining")
+		metrics.AbstainedPrices.WithLabelValues(price.Pair.String(), string(reason)).Inc()
+		prices[i] = types.NewAbstainPrice(price.Pair, price.SourceName, reason)
+	}
+	return prices
 }
 
 // Close gracefully shuts down the feeder.
@@ -119,3 +302,9 @@ func (f *Feeder) Close() {
 	close(f.stop)
 	<-f.done
 }
+
+// Done returns a channel which is closed once the feeder has stopped,
+// either because Close was called or because it decided to stop by itself.
+func (f *Feeder) Done() <-chan struct{} {
+	return f.done
+}
@@ -1,6 +1,7 @@
 package feeder
 
 import (
+	"context"
 	"io"
 	"testing"
 	"time"
@@ -22,7 +23,7 @@ func TestRunPanics(t *testing.T) {
 	eventStream := mocks.NewMockEventStream(ctrl)
 	eventStream.EXPECT().ParamsUpdate().Return(make(chan types.Params))
 
-	f := NewFeeder(eventStream, priceProvider, pricePoster, zerolog.New(io.Discard))
+	f := NewFeeder(eventStream, priceProvider, []types.PricePoster{pricePoster}, nil, zerolog.New(io.Discard))
 
 	require.Panics(t, func() {
 		f.Run()
@@ -60,17 +61,189 @@ func TestVotingPeriod(t *testing.T) {
 		Valid:      false,
 	}
 
-	abstainPrice := invalidPrice
-	abstainPrice.Price = 0.0
+	// the provider gave no reason for the invalid price
+	abstainPrice := types.NewAbstainPrice(invalidPrice.Pair, "mock-source", types.AbstainUnknown)
 
-	tf.mockPriceProvider.EXPECT().GetPrice(asset.Registry.Pair(denoms.BTC, denoms.NUSD)).Return(validPrice)
-	tf.mockPriceProvider.EXPECT().GetPrice(asset.Registry.Pair(denoms.ETH, denoms.NUSD)).Return(invalidPrice)
-	tf.mockPricePoster.EXPECT().SendPrices(gomock.Any(), []types.Price{validPrice, abstainPrice})
+	tf.mockPriceProvider.EXPECT().GetPrice(gomock.Any(), asset.Registry.Pair(denoms.BTC, denoms.NUSD)).Return(validPrice)
+	tf.mockPriceProvider.EXPECT().GetPrice(gomock.Any(), asset.Registry.Pair(denoms.ETH, denoms.NUSD)).Return(invalidPrice)
+	tf.mockPricePoster.EXPECT().SendPrices(gomock.Any(), gomock.Any(), []types.Price{validPrice, abstainPrice}).Do(func(ctx context.Context, _ types.VotingPeriod, _ []types.Price) {
+		// without a deadline estimate, posting is bounded by VotingPeriodTimeout
+		_, ok := ctx.Deadline()
+		require.True(t, ok)
+	})
 	// trigger voting period.
 	tf.newVotingPeriod <- types.VotingPeriod{Height: 100}
 	time.Sleep(10 * time.Millisecond)
 }
 
+func TestVotingPeriod_MultipleValidators(t *testing.T) {
+	tf := initFeeder(t)
+	defer tf.feeder.Close()
+
+	other := mocks.NewMockPricePoster(gomock.NewController(t))
+	other.EXPECT().Close()
+	other.EXPECT().Whoami().AnyTimes()
+	tf.feeder.pricePosters = append(tf.feeder.pricePosters, other)
+
+	price := types.Price{Pair: asset.Registry.Pair(denoms.BTC, denoms.NUSD), Price: 100_000.8, SourceName: "mock-source", Valid: true}
+	abstainPrice := types.NewAbstainPrice(asset.Registry.Pair(denoms.ETH, denoms.NUSD), "mock-source", types.AbstainStale)
+
+	// prices are fetched once and posted for every validator
+	tf.mockPriceProvider.EXPECT().GetPrice(gomock.Any(), price.Pair).Return(price)
+	tf.mockPriceProvider.EXPECT().GetPrice(gomock.Any(), abstainPrice.Pair).Return(abstainPrice)
+	tf.mockPricePoster.EXPECT().SendPrices(gomock.Any(), gomock.Any(), []types.Price{price, abstainPrice})
+	other.EXPECT().SendPrices(gomock.Any(), gomock.Any(), []types.Price{price, abstainPrice})
+	tf.newVotingPeriod <- types.VotingPeriod{Height: 100}
+	time.Sleep(10 * time.Millisecond)
+}
+
+func TestVotingPeriod_SlowPrice(t *testing.T) {
+	defer func(d time.Duration) { PriceGatheringTimeout = d }(PriceGatheringTimeout)
+	PriceGatheringTimeout = 20 * time.Millisecond
+
+	tf := initFeeder(t)
+	defer tf.feeder.Close()
+
+	btc, eth := asset.Registry.Pair(denoms.BTC, denoms.NUSD), asset.Registry.Pair(denoms.ETH, denoms.NUSD)
+	validPrice := types.Price{Pair: btc, Price: 100_000.8, SourceName: "mock-source", Valid: true}
+
+	// the slow pair is abstained from
+	tf.mockPriceProvider.EXPECT().GetPrice(gomock.Any(), btc).Return(validPrice)
+	tf.mockPriceProvider.EXPECT().GetPrice(gomock.Any(), eth).DoAndReturn(func(context.Context, asset.Pair) types.Price {
+		time.Sleep(100 * time.Millisecond)
+		return types.Price{Pair: eth, Price: 7000.11, SourceName: "mock-source", Valid: true}
+	})
+	tf.mockPricePoster.EXPECT().SendPrices(gomock.Any(), gomock.Any(), []types.Price{validPrice, types.NewAbstainPrice(eth, "", types.AbstainTimeout)})
+	tf.newVotingPeriod <- types.VotingPeriod{Height: 100}
+	time.Sleep(50 * time.Millisecond)
+}
+
+func TestVotingPeriod_Superseded(t *testing.T) {
+	tf := initFeeder(t)
+	defer tf.feeder.Close()
+
+	btc, eth := asset.Registry.Pair(denoms.BTC, denoms.NUSD), asset.Registry.Pair(denoms.ETH, denoms.NUSD)
+	validPrice := types.Price{Pair: btc, Price: 100_000.8, SourceName: "mock-source", Valid: true}
+	abstainPrice := types.NewAbstainPrice(eth, "mock-source", types.AbstainNotConfigured)
+
+	// the prices of the first voting period are still being gathered when the next one starts
+	release := make(chan struct{})
+	defer close(release)
+	tf.mockPriceProvider.EXPECT().GetPrice(gomock.Any(), btc).DoAndReturn(func(context.Context, asset.Pair) types.Price {
+		<-release
+		return validPrice
+	})
+	tf.mockPriceProvider.EXPECT().GetPrice(gomock.Any(), btc).Return(validPrice)
+	tf.mockPriceProvider.EXPECT().GetPrice(gomock.Any(), eth).Return(abstainPrice).Times(2)
+	tf.mockPricePoster.EXPECT().SendPrices(gomock.Any(), gomock.Any(), []types.Price{validPrice, abstainPrice}).Do(func(_ context.Context, vp types.VotingPeriod, _ []types.Price) {
+		require.Equal(t, uint64(101), vp.Height)
+	})
+
+	tf.newVotingPeriod <- types.VotingPeriod{Height: 100}
+	time.Sleep(10 * time.Millisecond)
+	tf.newVotingPeriod <- types.VotingPeriod{Height: 101}
+	time.Sleep(10 * time.Millisecond)
+}
+
+func TestVotingPeriod_Standby(t *testing.T) {
+	tf := initFeeder(t)
+	defer tf.feeder.Close()
+
+	election := mocks.NewMockLeaderElection(gomock.NewController(t))
+	election.EXPECT().Close()
+	tf.feeder.leaderElection = election
+
+	btc, eth := asset.Registry.Pair(denoms.BTC, denoms.NUSD), asset.Registry.Pair(denoms.ETH, denoms.NUSD)
+	validPrice := types.Price{Pair: btc, Price: 100_000.8, SourceName: "mock-source", Valid: true}
+	abstainPrice := types.NewAbstainPrice(eth, "mock-source", types.AbstainStale)
+
+	// prices are gathered in both voting periods but only sent while leading
+	tf.mockPriceProvider.EXPECT().GetPrice(gomock.Any(), btc).Return(validPrice).Times(2)
+	tf.mockPriceProvider.EXPECT().GetPrice(gomock.Any(), eth).Return(abstainPrice).Times(2)
+	gomock.InOrder(
+		election.EXPECT().IsLeader().Return(false),
+		election.EXPECT().IsLeader().Return(true),
+	)
+	election.EXPECT().Leading(gomock.Any()).DoAndReturn(func(ctx context.Context) (context.Context, context.CancelFunc) {
+		return context.WithCancel(ctx)
+	})
+	tf.mockPricePoster.EXPECT().SendPrices(gomock.Any(), gomock.Any(), []types.Price{validPrice, abstainPrice}).Do(func(_ context.Context, vp types.VotingPeriod, _ []types.Price) {
+		require.Equal(t, uint64(101), vp.Height)
+	})
+
+	tf.newVotingPeriod <- types.VotingPeriod{Height: 100}
+	time.Sleep(10 * time.Millisecond)
+	tf.newVotingPeriod <- types.VotingPeriod{Height: 101}
+	time.Sleep(10 * time.Millisecond)
+}
+
+func TestVotingPeriod_LeadershipLost(t *testing.T) {
+	tf := initFeeder(t)
+	defer tf.feeder.Close()
+
+	election := mocks.NewMockLeaderElection(gomock.NewController(t))
+	election.EXPECT().Close()
+	tf.feeder.leaderElection = election
+
+	btc, eth := asset.Registry.Pair(denoms.BTC, denoms.NUSD), asset.Registry.Pair(denoms.ETH, denoms.NUSD)
+	tf.mockPriceProvider.EXPECT().GetPrice(gomock.Any(), btc).Return(types.Price{Pair: btc, Price: 100_000.8, SourceName: "mock-source", Valid: true})
+	tf.mockPriceProvider.EXPECT().GetPrice(gomock.Any(), eth).Return(types.Price{Pair: eth, Price: 7000.11, SourceName: "mock-source", Valid: true})
+
+	// the leadership is lost while the prices are being sent
+	var loseLeadership context.CancelFunc
+	election.EXPECT().IsLeader().Return(true)
+	election.EXPECT().Leading(gomock.Any()).DoAndReturn(func(ctx context.Context) (context.Context, context.CancelFunc) {
+		ctx, loseLeadership = context.WithCancel(ctx)
+		return ctx, loseLeadership
+	})
+	sent := make(chan error, 1)
+	tf.mockPricePoster.EXPECT().SendPrices(gomock.Any(), gomock.Any(), gomock.Any()).Do(func(ctx context.Context, _ types.VotingPeriod, _ []types.Price) {
+		loseLeadership()
+		<-ctx.Done()
+		sent <- ctx.Err()
+	})
+
+	tf.newVotingPeriod <- types.VotingPeriod{Height: 100}
+	select {
+	case err := <-sent:
+		require.ErrorIs(t, err, context.Canceled)
+	case <-time.After(time.Second):
+		t.Fatal("prices still being sent after the leadership was lost")
+	}
+}
+
+func TestConnectionState(t *testing.T) {
+	defer func(d time.Duration) { MaxDisconnectedTime = d }(MaxDisconnectedTime)
+	MaxDisconnectedTime = 50 * time.Millisecond
+
+	t.Run("keeps running if connection recovers", func(t *testing.T) {
+		tf := initFeeder(t)
+		defer tf.feeder.Close()
+
+		tf.connectionState <- types.ConnectionStateDisconnected
+		tf.connectionState <- types.ConnectionStateConnecting
+		tf.connectionState <- types.ConnectionStateConnected
+		select {
+		case <-tf.feeder.Done():
+			t.Fatal("feeder stopped")
+		case <-time.After(2 * MaxDisconnectedTime):
+		}
+	})
+
+	t.Run("stops if disconnected for too long", func(t *testing.T) {
+		tf := initFeeder(t)
+
+		tf.connectionState <- types.ConnectionStateDisconnected
+		tf.connectionState <- types.ConnectionStateConnecting
+		select {
+		case <-tf.feeder.Done():
+		case <-time.After(time.Second):
+			t.Fatal("feeder did not stop")
+		}
+		require.NotPanics(t, tf.feeder.Close)
+	})
+}
+
 type testFeederHarness struct {
 	feeder            *Feeder
 	mockPriceProvider *mocks.MockPriceProvider
@@ -78,6 +251,7 @@ type testFeederHarness struct {
 	mockPricePoster   *mocks.MockPricePoster
 	newVotingPeriod   chan types.VotingPeriod
 	paramsChannel     chan types.Params
+	connectionState   chan types.ConnectionState
 }
 
 func initFeeder(t *testing.T) testFeederHarness {
@@ -93,11 +267,14 @@ func initFeeder(t *testing.T) testFeederHarness {
 	votingPeriodChannel := make(chan types.VotingPeriod, 1)
 	eventStream.EXPECT().VotingPeriodStarted().AnyTimes().Return(votingPeriodChannel)
 
+	connectionStateChannel := make(chan types.ConnectionState, 1)
+	eventStream.EXPECT().ConnectionStateChanged().AnyTimes().Return(connectionStateChannel)
+
 	feeder := &Feeder{
 		stop:          make(chan struct{}),
 		done:          make(chan struct{}),
 		eventStream:   eventStream,
-		pricePoster:   pricePoster,
+		pricePosters:  []types.PricePoster{pricePoster},
 		priceProvider: priceProvider,
 		params:        types.Params{},
 		logger:        zerolog.New(io.Discard),
@@ -107,6 +284,7 @@ func initFeeder(t *testing.T) testFeederHarness {
 	eventStream.EXPECT().Close()
 	priceProvider.EXPECT().Close()
 	pricePoster.EXPECT().Close()
+	pricePoster.EXPECT().Whoami().AnyTimes()
 
 	return testFeederHarness{
 		feeder:            feeder,
@@ -115,5 +293,6 @@ func initFeeder(t *testing.T) testFeederHarness {
 		mockPricePoster:   pricePoster,
 		newVotingPeriod:   votingPeriodChannel,
 		paramsChannel:     paramsChannel,
+		connectionState:   connectionStateChannel,
 	}
 }
@@ -59,8 +59,7 @@ func (s *IntegrationTestSuite) SetupSuite() {
 	s.logs = new(bytes.Buffer)
 	log := zerolog.New(io.MultiWriter(os.Stderr, s.logs)).Level(zerolog.InfoLevel)
 
-	enableTLS := false
-	eventStream := eventstream.Dial(u.String(), grpcEndpoint, enableTLS, log)
+	eventStream := eventstream.Dial(u.String(), grpcEndpoint, eventstream.DefaultSubscription, nil, log)
 	priceProvider := priceprovider.NewPriceProvider(sources.Bitfinex, map[asset.Pair]types.Symbol{
 		asset.Registry.Pair(denoms.BTC, denoms.NUSD): "tBTCUSD",
 		asset.Registry.Pair(denoms.ETH, denoms.NUSD): "tETHUSD",
@@ -68,9 +67,10 @@ func (s *IntegrationTestSuite) SetupSuite() {
 	pricePoster := priceposter.Dial(
 		grpcEndpoint,
 		s.cfg.ChainID,
-		enableTLS,
+		nil,
+		priceposter.DefaultFeeConfig(),
 		val.ClientCtx.Keyring, val.ValAddress, val.Address, log)
-	s.feeder = feeder.NewFeeder(eventStream, priceProvider, pricePoster, log)
+	s.feeder = feeder.NewFeeder(eventStream, priceProvider, []types.PricePoster{pricePoster}, nil, log)
 	s.feeder.Run()
 }
 
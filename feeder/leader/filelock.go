@@ -0,0 +1,113 @@
+package leader
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/NibiruChain/pricefeeder/types"
+	"github.com/rs/zerolog"
+)
+
+// LockRetryInterval is how often a standby feeder tries to take the lock.
+var LockRetryInterval = time.Second
+
+var _ types.LeaderElection = (*FileLock)(nil)
+
+// FileLock elects the feeder holding an exclusive lock on a file, usually on a volume shared by the feeders.
+// The lock is held until Close, or until the process exits, after which a standby feeder takes it
+// within LockRetryInterval.
+type FileLock struct {
+	status
+	file *os.File
+	id   string
+	stop chan struct{}
+	done chan struct{}
+}
+
+// NewFileLock opens the lock file, creating it if needed, and starts trying to take the lock.
+// Once taken, the id of the feeder is written to the file for operators to see who leads.
+func NewFileLock(path, id string, logger zerolog.Logger) (*FileLock, error) {
+	file, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open the lock file: %w", err)
+	}
+	l := &FileLock{
+		status: status{logger: logger.With().Str("component", "leader-election").Str("lock-file", path).Logger()},
+		file:   file,
+		id:     id,
+		stop:   make(chan struct{}),
+		done:   make(chan struct{}),
+	}
+	go l.loop()
+	return l, nil
+}
+
+func (l *FileLock) loop() {
+	defer close(l.done)
+
+	ticker := time.NewTicker(LockRetryInterval)
+	defer ticker.Stop()
+	for {
+		locked, err := tryLockFile(l.file)
+		if err != nil {
+			l.logger.Err(err).Msg("failed to take the lock")
+		}
+		if locked {
+			if err := l.writeID(); err != nil {
+				l.logger.Err(err).Msg("failed to write the leader id to the lock file")
+			}
+			l.set(true)
+			return
+		}
+		select {
+		case <-l.stop:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (l *FileLock) writeID() error {
+	if err := l.file.Truncate(0); err != nil {
+		return err
+	}
+	_, err := l.file.WriteAt([]byte(l.id+"\n"), 0)
+	return err
+}
+
+// IsLeader reports whether the lock is held.
+func (l *FileLock) IsLeader() bool {
+	return l.get()
+}
+
+// Leading returns a copy of ctx cancelled once the lock is released on Close, or right away if it is not held.
+func (l *FileLock) Leading(ctx context.Context) (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithCancel(ctx)
+	if !l.IsLeader() {
+		cancel()
+		return ctx, cancel
+	}
+	go func() {
+		select {
+		case <-ctx.Done():
+		case <-l.stop:
+			cancel()
+		}
+	}()
+	return ctx, cancel
+}
+
+// Close releases the lock, if held, and stops trying to take it.
+func (l *FileLock) Close() {
+	close(l.stop)
+	<-l.done
+	if l.get() {
+		l.set(false)
+		if err := unlockFile(l.file); err != nil {
+			l.logger.Err(err).Msg("failed to release the lock")
+		}
+	}
+	_ = l.file.Close()
+}
@@ -0,0 +1,17 @@
+//go:build !unix
+
+package leader
+
+import (
+	"fmt"
+	"os"
+	"runtime"
+)
+
+func tryLockFile(*os.File) (bool, error) {
+	return false, fmt.Errorf("file locks are not supported on %s", runtime.GOOS)
+}
+
+func unlockFile(*os.File) error {
+	return nil
+}
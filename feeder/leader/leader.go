@@ -0,0 +1,42 @@
+// Package leader implements the leader elections letting two feeders run for the same validators
+// in active/standby, with only the leader posting prices:
+//
+//   - FileLock elects the feeder holding a lock on a file of a shared volume.
+//   - Lease elects the feeder holding a lease from an HTTP lease server, such as the one of NewLeaseServer.
+package leader
+
+import (
+	"sync"
+
+	"github.com/NibiruChain/pricefeeder/metrics"
+	"github.com/rs/zerolog"
+)
+
+// status tracks whether this feeder leads, reporting the changes.
+type status struct {
+	logger zerolog.Logger
+	mu     sync.Mutex
+	leader bool
+}
+
+func (s *status) set(leader bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if leader == s.leader {
+		return
+	}
+	s.leader = leader
+	if leader {
+		s.logger.Info().Msg("became the leader, posting prices")
+		metrics.Leader.Set(1)
+	} else {
+		s.logger.Warn().Msg("no longer the leader, standing by")
+		metrics.Leader.Set(0)
+	}
+}
+
+func (s *status) get() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.leader
+}
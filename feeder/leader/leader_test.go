@@ -0,0 +1,136 @@
+package leader
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileLock(t *testing.T) {
+	defer func(d time.Duration) { LockRetryInterval = d }(LockRetryInterval)
+	LockRetryInterval = 10 * time.Millisecond
+
+	path := filepath.Join(t.TempDir(), "leader.lock")
+	active, err := NewFileLock(path, "active", zerolog.New(io.Discard))
+	require.NoError(t, err)
+	require.Eventually(t, active.IsLeader, time.Second, 10*time.Millisecond)
+
+	standby, err := NewFileLock(path, "standby", zerolog.New(io.Discard))
+	require.NoError(t, err)
+	defer standby.Close()
+	time.Sleep(5 * LockRetryInterval)
+	require.False(t, standby.IsLeader())
+	id, err := os.ReadFile(path)
+	require.NoError(t, err)
+	require.Equal(t, "active\n", string(id))
+
+	ctx, cancel := standby.Leading(context.Background())
+	defer cancel()
+	require.Error(t, ctx.Err())
+	leading, cancel := active.Leading(context.Background())
+	defer cancel()
+	require.NoError(t, leading.Err())
+
+	// the standby takes over once the lock is released
+	active.Close()
+	require.False(t, active.IsLeader())
+	require.Eventually(t, func() bool { return leading.Err() != nil }, time.Second, 10*time.Millisecond)
+	require.Eventually(t, standby.IsLeader, time.Second, 10*time.Millisecond)
+	id, err = os.ReadFile(path)
+	require.NoError(t, err)
+	require.Equal(t, "standby\n", string(id))
+}
+
+func TestLease(t *testing.T) {
+	server := httptest.NewServer(NewLeaseServer(zerolog.New(io.Discard)).Handler())
+	defer server.Close()
+	url := server.URL + leasesPath + "validator"
+	ttl := 300 * time.Millisecond
+
+	active := NewLease(url, "active", ttl, zerolog.New(io.Discard))
+	require.Eventually(t, active.IsLeader, time.Second, 10*time.Millisecond)
+
+	standby := NewLease(url, "standby", ttl, zerolog.New(io.Discard))
+	defer standby.Close()
+	time.Sleep(ttl)
+	require.True(t, active.IsLeader())
+	require.False(t, standby.IsLeader())
+
+	// the leadership outlives the TTL as long as the lease is renewed
+	leading, cancel := active.Leading(context.Background())
+	defer cancel()
+	time.Sleep(2 * ttl)
+	require.NoError(t, leading.Err())
+
+	// the standby takes over once the lease is released
+	active.Close()
+	require.False(t, active.IsLeader())
+	require.Eventually(t, func() bool { return leading.Err() != nil }, time.Second, 10*time.Millisecond)
+	require.Eventually(t, standby.IsLeader, ttl, 10*time.Millisecond)
+}
+
+func TestLease_ServerUnreachable(t *testing.T) {
+	server := httptest.NewServer(NewLeaseServer(zerolog.New(io.Discard)).Handler())
+	ttl := 300 * time.Millisecond
+
+	l := NewLease(server.URL+leasesPath+"validator", "active", ttl, zerolog.New(io.Discard))
+	defer l.Close()
+	require.Eventually(t, l.IsLeader, time.Second, 10*time.Millisecond)
+
+	// the leadership runs out with the lease when it can't be renewed
+	leading, cancel := l.Leading(context.Background())
+	defer cancel()
+	server.Close()
+	require.Eventually(t, func() bool { return !l.IsLeader() }, 2*ttl, 10*time.Millisecond)
+	require.Eventually(t, func() bool { return leading.Err() != nil }, 10*time.Millisecond, time.Millisecond)
+}
+
+func TestLeaseServer(t *testing.T) {
+	server := httptest.NewServer(NewLeaseServer(zerolog.New(io.Discard)).Handler())
+	defer server.Close()
+	url := server.URL + leasesPath + "validator"
+
+	send := func(method, body string) (int, string) {
+		req, err := http.NewRequest(method, url, strings.NewReader(body))
+		require.NoError(t, err)
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		b, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		return resp.StatusCode, strings.TrimSpace(string(b))
+	}
+
+	status, body := send(http.MethodPut, `{"holder": "a", "ttl_ms": 50}`)
+	require.Equal(t, http.StatusOK, status)
+	require.JSONEq(t, `{"holder": "a"}`, body)
+
+	status, body = send(http.MethodPut, `{"holder": "b", "ttl_ms": 50}`)
+	require.Equal(t, http.StatusConflict, status)
+	require.JSONEq(t, `{"holder": "a"}`, body)
+
+	// releasing a lease held by another holder is a no-op
+	_, body = send(http.MethodDelete, `{"holder": "b"}`)
+	require.JSONEq(t, `{"holder": "a"}`, body)
+
+	// an expired lease is granted to the next holder
+	time.Sleep(60 * time.Millisecond)
+	status, _ = send(http.MethodPut, `{"holder": "b", "ttl_ms": 50}`)
+	require.Equal(t, http.StatusOK, status)
+	_, body = send(http.MethodGet, "")
+	require.JSONEq(t, `{"holder": "b"}`, body)
+
+	status, _ = send(http.MethodPut, `{"holder": "b"}`)
+	require.Equal(t, http.StatusBadRequest, status)
+	status, _ = send(http.MethodPost, `{"holder": "b", "ttl_ms": 50}`)
+	require.Equal(t, http.StatusMethodNotAllowed, status)
+}
@@ -0,0 +1,161 @@
+package leader
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync/atomic"
+	"time"
+
+	"github.com/NibiruChain/pricefeeder/types"
+	"github.com/rs/zerolog"
+)
+
+// leaseRequest is the body of the requests to a lease server.
+type leaseRequest struct {
+	Holder string `json:"holder"`
+	TTLMs  int64  `json:"ttl_ms,omitempty"`
+}
+
+// leaseResponse is the body of the responses of a lease server, with the current holder of the lease.
+type leaseResponse struct {
+	Holder string `json:"holder"`
+}
+
+var _ types.LeaderElection = (*Lease)(nil)
+
+// Lease elects the feeder holding a lease from an HTTP lease server. The lease is acquired, and then
+// renewed, with a PUT of its URL every third of its TTL and released with a DELETE on Close.
+// The server answers 409 Conflict while the lease of another holder runs.
+//
+// The feeder only leads until the TTL has elapsed since its last successful renewal was sent,
+// which is before the lease expires on the server, so that two feeders never lead at once.
+type Lease struct {
+	status
+	url       string
+	id        string
+	ttl       time.Duration
+	client    *http.Client
+	expiresAt atomic.Int64 // unix nanoseconds at which the lease runs out, as seen by this feeder
+	stop      chan struct{}
+	done      chan struct{}
+}
+
+// NewLease starts acquiring the lease at the given URL of a lease server for the feeder of the given id.
+func NewLease(url, id string, ttl time.Duration, logger zerolog.Logger) *Lease {
+	l := &Lease{
+		status: status{logger: logger.With().Str("component", "leader-election").Str("lease", url).Logger()},
+		url:    url,
+		id:     id,
+		ttl:    ttl,
+		client: &http.Client{Timeout: ttl / 3},
+		stop:   make(chan struct{}),
+		done:   make(chan struct{}),
+	}
+	go l.loop()
+	return l
+}
+
+func (l *Lease) loop() {
+	defer close(l.done)
+
+	ticker := time.NewTicker(l.ttl / 3)
+	defer ticker.Stop()
+	for {
+		l.renew()
+		select {
+		case <-l.stop:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// renew acquires or renews the lease.
+func (l *Lease) renew() {
+	sentAt := time.Now()
+	holder, err := l.do(http.MethodPut, l.ttl)
+	switch {
+	case err != nil:
+		l.logger.Err(err).Msg("failed to renew the lease")
+	case holder == l.id:
+		l.expiresAt.Store(sentAt.Add(l.ttl).UnixNano())
+	default:
+		l.expiresAt.Store(0)
+		l.logger.Debug().Str("holder", holder).Msg("lease held by another feeder")
+	}
+	l.set(l.running())
+}
+
+func (l *Lease) running() bool {
+	return time.Now().UnixNano() < l.expiresAt.Load()
+}
+
+// do sends a request for the lease and returns its current holder.
+func (l *Lease) do(method string, ttl time.Duration) (string, error) {
+	body, err := json.Marshal(leaseRequest{Holder: l.id, TTLMs: ttl.Milliseconds()})
+	if err != nil {
+		return "", err
+	}
+	req, err := http.NewRequest(method, l.url, bytes.NewReader(body))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := l.client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusConflict {
+		return "", fmt.Errorf("lease server answered %s", resp.Status)
+	}
+	var lease leaseResponse
+	if err := json.NewDecoder(resp.Body).Decode(&lease); err != nil {
+		return "", fmt.Errorf("invalid lease server response: %w", err)
+	}
+	return lease.Holder, nil
+}
+
+// IsLeader reports whether the lease is held and has not run out.
+func (l *Lease) IsLeader() bool {
+	return l.get() && l.running()
+}
+
+// Leading returns a copy of ctx cancelled once the lease runs out without having been renewed,
+// or is released on Close, or right away if it is not held.
+func (l *Lease) Leading(ctx context.Context) (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithCancel(ctx)
+	go func() {
+		defer cancel()
+		for l.IsLeader() {
+			timer := time.NewTimer(time.Until(time.Unix(0, l.expiresAt.Load())))
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return
+			case <-l.stop:
+				timer.Stop()
+				return
+			case <-timer.C:
+			}
+		}
+	}()
+	return ctx, cancel
+}
+
+// Close releases the lease, if held, so that a standby feeder can take it without waiting for it to run out.
+func (l *Lease) Close() {
+	close(l.stop)
+	<-l.done
+	if l.get() {
+		l.expiresAt.Store(0)
+		l.set(false)
+		if _, err := l.do(http.MethodDelete, 0); err != nil {
+			l.logger.Err(err).Msg("failed to release the lease")
+		}
+	}
+}
@@ -0,0 +1,101 @@
+package leader
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+// leasesPath is the path prefix of the leases served by LeaseServer, followed by the lease name.
+const leasesPath = "/v1/leases/"
+
+// LeaseServer is an in-memory lease server for Lease, holding any number of named leases.
+// It's a stand-in for a replicated store, and the leases are lost when it restarts.
+type LeaseServer struct {
+	logger zerolog.Logger
+	mu     sync.Mutex
+	leases map[string]lease
+}
+
+type lease struct {
+	holder    string
+	expiresAt time.Time
+}
+
+// NewLeaseServer returns a LeaseServer with no leases.
+func NewLeaseServer(logger zerolog.Logger) *LeaseServer {
+	return &LeaseServer{
+		logger: logger.With().Str("component", "lease-server").Logger(),
+		leases: map[string]lease{},
+	}
+}
+
+// Handler returns the HTTP handler serving the leases at /v1/leases/{name}:
+// GET returns the holder, PUT acquires or renews the lease and DELETE releases it.
+func (s *LeaseServer) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc(leasesPath, s.handleLease)
+	return mux
+}
+
+func (s *LeaseServer) handleLease(w http.ResponseWriter, r *http.Request) {
+	name := strings.TrimPrefix(r.URL.Path, leasesPath)
+	if name == "" {
+		http.NotFound(w, r)
+		return
+	}
+
+	var req leaseRequest
+	if r.Method != http.MethodGet {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Holder == "" {
+			http.Error(w, "invalid lease request", http.StatusBadRequest)
+			return
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := time.Now()
+	current := s.leases[name]
+	if !now.Before(current.expiresAt) {
+		current = lease{}
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		writeLease(w, http.StatusOK, current.holder)
+	case http.MethodPut:
+		if req.TTLMs <= 0 {
+			http.Error(w, "invalid lease ttl", http.StatusBadRequest)
+			return
+		}
+		if current.holder != "" && current.holder != req.Holder {
+			writeLease(w, http.StatusConflict, current.holder)
+			return
+		}
+		if current.holder != req.Holder {
+			s.logger.Info().Str("lease", name).Str("holder", req.Holder).Msg("lease acquired")
+		}
+		s.leases[name] = lease{holder: req.Holder, expiresAt: now.Add(time.Duration(req.TTLMs) * time.Millisecond)}
+		writeLease(w, http.StatusOK, req.Holder)
+	case http.MethodDelete:
+		if current.holder == req.Holder {
+			s.logger.Info().Str("lease", name).Str("holder", req.Holder).Msg("lease released")
+			delete(s.leases, name)
+			current = lease{}
+		}
+		writeLease(w, http.StatusOK, current.holder)
+	default:
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+func writeLease(w http.ResponseWriter, status int, holder string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(leaseResponse{Holder: holder})
+}
@@ -0,0 +1,102 @@
+package priceposter
+
+import (
+	"context"
+	"sync"
+
+	"github.com/NibiruChain/pricefeeder/metrics"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	"github.com/rs/zerolog"
+)
+
+// feeTracker remembers the fees of the last tx accepted by the mempool.
+type feeTracker struct {
+	mu   sync.Mutex
+	fees sdk.Coins
+}
+
+func (t *feeTracker) set(fees sdk.Coins) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.fees = fees
+}
+
+func (t *feeTracker) get() sdk.Coins {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.fees
+}
+
+// BalanceThresholds are the balances of the account paying the fees below which the balance monitor
+// logs warnings and errors. Only the denoms present in the thresholds are checked.
+type BalanceThresholds struct {
+	Warning  sdk.Coins
+	Critical sdk.Coins
+}
+
+// balanceMonitor tracks the balance of the account paying the fees in the fee denoms,
+// and how many more txs it can pay for at the current fees.
+type balanceMonitor struct {
+	bankClient Bank
+	payer      sdk.AccAddress
+	feeDenoms  []string
+	thresholds BalanceThresholds
+	lastFees   *feeTracker
+	logger     zerolog.Logger
+}
+
+// check queries the balance of the payer in every fee denom and reports it.
+func (m *balanceMonitor) check(ctx context.Context) error {
+	balances := sdk.NewCoins()
+	for _, denom := range m.feeDenoms {
+		resp, err := m.bankClient.Balance(ctx, &banktypes.QueryBalanceRequest{Address: m.payer.String(), Denom: denom})
+		if err != nil {
+			return err
+		}
+		balances = balances.Add(*resp.Balance)
+		metrics.FeederBalance.WithLabelValues(m.payer.String(), denom).Set(sdk.NewDecFromInt(resp.Balance.Amount).MustFloat64())
+	}
+
+	logger := m.logger.With().Str("balance", balances.String()).Logger()
+	if remaining, ok := remainingTxs(balances, m.lastFees.get()); ok {
+		metrics.RemainingVotes.WithLabelValues(m.payer.String()).Set(float64(remaining))
+		logger = logger.With().Uint64("remaining-votes", remaining).Logger()
+	}
+
+	switch {
+	case isBelow(balances, m.thresholds.Critical):
+		logger.Error().Str("threshold", m.thresholds.Critical.String()).Msg("feeder balance is critically low, fund it to keep voting")
+	case isBelow(balances, m.thresholds.Warning):
+		logger.Warn().Str("threshold", m.thresholds.Warning.String()).Msg("feeder balance is low")
+	default:
+		logger.Debug().Msg("feeder balance checked")
+	}
+	return nil
+}
+
+// isBelow reports whether the balance is lower than the threshold in any of the threshold's denoms.
+func isBelow(balances sdk.Coins, threshold sdk.Coins) bool {
+	for _, coin := range threshold {
+		if balances.AmountOf(coin.Denom).LT(coin.Amount) {
+			return true
+		}
+	}
+	return false
+}
+
+// remainingTxs returns how many txs paying the given fees the balances can pay for.
+// It returns false if the fees are unknown.
+func remainingTxs(balances sdk.Coins, fees sdk.Coins) (uint64, bool) {
+	if fees.IsZero() {
+		return 0, false
+	}
+	var remaining *uint64
+	for _, fee := range fees {
+		n := balances.AmountOf(fee.Denom).Quo(fee.Amount).Uint64()
+		if remaining == nil || n < *remaining {
+			remaining = &n
+		}
+	}
+	return *remaining, true
+}
@@ -0,0 +1,69 @@
+package priceposter
+
+import (
+	"context"
+	"io"
+	"testing"
+
+	"github.com/NibiruChain/pricefeeder/metrics"
+	"github.com/cosmos/cosmos-sdk/crypto/keys/secp256k1"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+)
+
+type mockBank struct {
+	balances sdk.Coins
+}
+
+func (m mockBank) Balance(_ context.Context, req *banktypes.QueryBalanceRequest, _ ...grpc.CallOption) (*banktypes.QueryBalanceResponse, error) {
+	balance := sdk.NewCoin(req.Denom, m.balances.AmountOf(req.Denom))
+	return &banktypes.QueryBalanceResponse{Balance: &balance}, nil
+}
+
+func TestIsBelow(t *testing.T) {
+	balances := sdk.NewCoins(sdk.NewInt64Coin("unibi", 1_000), sdk.NewInt64Coin("uusd", 10))
+	require.False(t, isBelow(balances, nil))
+	require.False(t, isBelow(balances, sdk.NewCoins(sdk.NewInt64Coin("unibi", 1_000))))
+	require.True(t, isBelow(balances, sdk.NewCoins(sdk.NewInt64Coin("unibi", 1_001))))
+	require.True(t, isBelow(balances, sdk.NewCoins(sdk.NewInt64Coin("uatom", 1))))
+}
+
+func TestRemainingTxs(t *testing.T) {
+	balances := sdk.NewCoins(sdk.NewInt64Coin("unibi", 1_000), sdk.NewInt64Coin("uusd", 10))
+
+	_, ok := remainingTxs(balances, nil)
+	require.False(t, ok)
+
+	remaining, ok := remainingTxs(balances, sdk.NewCoins(sdk.NewInt64Coin("unibi", 125)))
+	require.True(t, ok)
+	require.Equal(t, uint64(8), remaining)
+
+	// the scarcest denom limits the number of txs
+	remaining, ok = remainingTxs(balances, sdk.NewCoins(sdk.NewInt64Coin("unibi", 125), sdk.NewInt64Coin("uusd", 5)))
+	require.True(t, ok)
+	require.Equal(t, uint64(2), remaining)
+}
+
+func TestBalanceMonitor(t *testing.T) {
+	feeder := sdk.AccAddress(secp256k1.GenPrivKey().PubKey().Address())
+	lastFees := new(feeTracker)
+	m := &balanceMonitor{
+		bankClient: mockBank{balances: sdk.NewCoins(sdk.NewInt64Coin("unibi", 10_000))},
+		payer:      feeder,
+		feeDenoms:  []string{"unibi"},
+		thresholds: BalanceThresholds{Warning: sdk.NewCoins(sdk.NewInt64Coin("unibi", 50_000))},
+		lastFees:   lastFees,
+		logger:     zerolog.New(io.Discard),
+	}
+
+	require.NoError(t, m.check(context.Background()))
+	require.Equal(t, 10_000.0, testutil.ToFloat64(metrics.FeederBalance.WithLabelValues(feeder.String(), "unibi")))
+
+	lastFees.set(sdk.NewCoins(sdk.NewInt64Coin("unibi", 125)))
+	require.NoError(t, m.check(context.Background()))
+	require.Equal(t, 80.0, testutil.ToFloat64(metrics.RemainingVotes.WithLabelValues(feeder.String())))
+}
@@ -0,0 +1,97 @@
+package priceposter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/NibiruChain/pricefeeder/metrics"
+	abcitypes "github.com/cometbft/cometbft/abci/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	txservice "github.com/cosmos/cosmos-sdk/types/tx"
+	"github.com/rs/zerolog"
+)
+
+var (
+	// MaxBroadcastAttempts is how many times a tx rejected by the mempool is sent before giving up.
+	MaxBroadcastAttempts = 3
+	// FeeBumpFactor multiplies the gas prices on every new broadcast attempt.
+	FeeBumpFactor = 1.25
+	// InclusionPollInterval is the wait time between queries for a broadcast tx.
+	InclusionPollInterval = 1 * time.Second
+)
+
+// Outcomes of a broadcast tx, as reported to metrics.TxBroadcastLatency.
+const (
+	txOutcomeIncluded = "included"
+	txOutcomeFailed   = "failed"
+	txOutcomeExpired  = "expired"
+)
+
+// errTxExpired is returned when a tx is not included in a block before the context is done.
+var errTxExpired = errors.New("tx not included in a block in time")
+
+// isRetryable reports whether a tx rejected by the mempool may be accepted if sent again:
+// the sequence was resynced or the fees can be bumped.
+func isRetryable(resp *sdk.TxResponse, err error) bool {
+	if _, mismatch := parseSequenceMismatch(err); mismatch {
+		return true
+	}
+	if resp == nil || resp.Codespace != sdkerrors.RootCodespace {
+		return false
+	}
+	return resp.Code == sdkerrors.ErrInsufficientFee.ABCICode() || resp.Code == sdkerrors.ErrMempoolIsFull.ABCICode()
+}
+
+// bumpGasPrices returns the gas prices to use for the given broadcast attempt,
+// which grow by FeeBumpFactor on every attempt.
+func bumpGasPrices(prices sdk.DecCoins, attempt int) sdk.DecCoins {
+	if attempt == 0 || prices.IsZero() {
+		return prices
+	}
+	return prices.MulDec(float64ToDec(math.Pow(FeeBumpFactor, float64(attempt))))
+}
+
+// awaitInclusion polls the node until the tx with the given hash is included in a block,
+// and returns an error if it failed or if the context is done before it was included.
+// The outcome and the time elapsed since sentAt are recorded in metrics.TxBroadcastLatency.
+func awaitInclusion(
+	ctx context.Context,
+	txClient TxService,
+	validator sdk.ValAddress,
+	hash string,
+	txType string,
+	sentAt time.Time,
+	logger zerolog.Logger,
+) (*sdk.TxResponse, error) {
+	observe := func(outcome string) {
+		metrics.TxBroadcastLatency.WithLabelValues(validator.String(), txType, outcome).Observe(time.Since(sentAt).Seconds())
+	}
+
+	tick := time.NewTicker(InclusionPollInterval)
+	defer tick.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			observe(txOutcomeExpired)
+			return nil, errTxExpired
+		case <-tick.C:
+		}
+
+		// the node returns an error until the tx is included
+		resp, err := txClient.GetTx(ctx, &txservice.GetTxRequest{Hash: hash})
+		if err != nil {
+			logger.Debug().Err(err).Str("tx-hash", hash).Msg("tx not found yet")
+			continue
+		}
+		if resp.TxResponse.Code != abcitypes.CodeTypeOK {
+			observe(txOutcomeFailed)
+			return resp.TxResponse, fmt.Errorf("tx failed in block %d: %s", resp.TxResponse.Height, resp.TxResponse.RawLog)
+		}
+		observe(txOutcomeIncluded)
+		return resp.TxResponse, nil
+	}
+}
@@ -0,0 +1,71 @@
+package priceposter
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	txservice "github.com/cosmos/cosmos-sdk/types/tx"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsRetryable(t *testing.T) {
+	require.True(t, isRetryable(nil, errors.New("account sequence mismatch, expected 5, got 4: incorrect account sequence")))
+	require.True(t, isRetryable(&sdk.TxResponse{Codespace: sdkerrors.RootCodespace, Code: sdkerrors.ErrInsufficientFee.ABCICode()}, errors.New("tx failed")))
+	require.True(t, isRetryable(&sdk.TxResponse{Codespace: sdkerrors.RootCodespace, Code: sdkerrors.ErrMempoolIsFull.ABCICode()}, errors.New("tx failed")))
+	require.False(t, isRetryable(&sdk.TxResponse{Codespace: sdkerrors.RootCodespace, Code: sdkerrors.ErrTxInMempoolCache.ABCICode()}, errors.New("tx failed")))
+	require.False(t, isRetryable(&sdk.TxResponse{Codespace: "oracle", Code: sdkerrors.ErrInsufficientFee.ABCICode()}, errors.New("tx failed")))
+	require.False(t, isRetryable(nil, errors.New("connection refused")))
+}
+
+func TestBumpGasPrices(t *testing.T) {
+	prices := mustDecCoins(t, "0.04unibi")
+	require.Equal(t, prices, bumpGasPrices(prices, 0))
+	require.Equal(t, "0.050000000000000000unibi", bumpGasPrices(prices, 1).String())
+	require.Equal(t, "0.062500000000000000unibi", bumpGasPrices(prices, 2).String())
+	require.True(t, bumpGasPrices(nil, 1).IsZero())
+}
+
+func TestAwaitInclusion(t *testing.T) {
+	defer func(interval time.Duration) { InclusionPollInterval = interval }(InclusionPollInterval)
+	InclusionPollInterval = time.Millisecond
+	logger := zerolog.New(io.Discard)
+
+	// the tx is found after a few queries
+	getTx := func(code uint32) func(*txservice.GetTxRequest) (*txservice.GetTxResponse, error) {
+		queries := 0
+		return func(req *txservice.GetTxRequest) (*txservice.GetTxResponse, error) {
+			queries++
+			if queries < 3 {
+				return nil, errors.New("tx not found")
+			}
+			return &txservice.GetTxResponse{TxResponse: &sdk.TxResponse{TxHash: req.Hash, Height: 10, Code: code}}, nil
+		}
+	}
+
+	t.Run("included", func(t *testing.T) {
+		resp, err := awaitInclusion(context.Background(), mockTxService{getTx: getTx(0)}, nil, "HASH", txTypePrevote, time.Now(), logger)
+		require.NoError(t, err)
+		require.Equal(t, int64(10), resp.Height)
+	})
+
+	t.Run("failed", func(t *testing.T) {
+		_, err := awaitInclusion(context.Background(), mockTxService{getTx: getTx(5)}, nil, "HASH", txTypePrevote, time.Now(), logger)
+		require.ErrorContains(t, err, "tx failed in block 10")
+	})
+
+	t.Run("expired", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+		notFound := mockTxService{getTx: func(*txservice.GetTxRequest) (*txservice.GetTxResponse, error) {
+			return nil, errors.New("tx not found")
+		}}
+		_, err := awaitInclusion(ctx, notFound, nil, "HASH", txTypePrevote, time.Now(), logger)
+		require.ErrorIs(t, err, errTxExpired)
+	})
+}
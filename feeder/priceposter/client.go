@@ -3,6 +3,8 @@ package priceposter
 import (
 	"context"
 	"crypto/tls"
+	"errors"
+	"sync"
 	"time"
 
 	"github.com/NibiruChain/nibiru/app"
@@ -10,13 +12,14 @@ import (
 	"github.com/NibiruChain/pricefeeder/metrics"
 	"github.com/NibiruChain/pricefeeder/types"
 	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/client/grpc/node"
 	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
 	"github.com/cosmos/cosmos-sdk/crypto/keyring"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	txservice "github.com/cosmos/cosmos-sdk/types/tx"
 	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
-	"github.com/prometheus/client_golang/prometheus"
-	"github.com/prometheus/client_golang/prometheus/promauto"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	"github.com/cosmos/cosmos-sdk/x/feegrant"
 	"github.com/rs/zerolog"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
@@ -28,6 +31,10 @@ var _ types.PricePoster = (*Client)(nil)
 // Oracle interface defines the gRPC methods used for oracle operations
 type Oracle interface {
 	AggregatePrevote(context.Context, *oracletypes.QueryAggregatePrevoteRequest, ...grpc.CallOption) (*oracletypes.QueryAggregatePrevoteResponse, error)
+	AggregateVote(context.Context, *oracletypes.QueryAggregateVoteRequest, ...grpc.CallOption) (*oracletypes.QueryAggregateVoteResponse, error)
+	FeederDelegation(context.Context, *oracletypes.QueryFeederDelegationRequest, ...grpc.CallOption) (*oracletypes.QueryFeederDelegationResponse, error)
+	MissCounter(context.Context, *oracletypes.QueryMissCounterRequest, ...grpc.CallOption) (*oracletypes.QueryMissCounterResponse, error)
+	Params(context.Context, *oracletypes.QueryParamsRequest, ...grpc.CallOption) (*oracletypes.QueryParamsResponse, error)
 }
 
 // Auth interface defines the gRPC methods for account operations
@@ -38,45 +45,59 @@ type Auth interface {
 // TxService interface defines the gRPC methods for transaction operations
 type TxService interface {
 	BroadcastTx(context.Context, *txservice.BroadcastTxRequest, ...grpc.CallOption) (*txservice.BroadcastTxResponse, error)
+	Simulate(context.Context, *txservice.SimulateRequest, ...grpc.CallOption) (*txservice.SimulateResponse, error)
+	GetTx(context.Context, *txservice.GetTxRequest, ...grpc.CallOption) (*txservice.GetTxResponse, error)
+}
+
+// Bank interface defines the gRPC methods for querying balances
+type Bank interface {
+	Balance(context.Context, *banktypes.QueryBalanceRequest, ...grpc.CallOption) (*banktypes.QueryBalanceResponse, error)
+}
+
+// FeeGrant interface defines the gRPC methods for querying fee allowances
+type FeeGrant interface {
+	Allowance(context.Context, *feegrant.QueryAllowanceRequest, ...grpc.CallOption) (*feegrant.QueryAllowanceResponse, error)
+}
+
+// Node interface defines the gRPC methods for querying the node's configuration
+type Node interface {
+	Config(context.Context, *node.ConfigRequest, ...grpc.CallOption) (*node.ConfigResponse, error)
 }
 
 // deps contains all the dependencies required for transaction creation and submission
 type deps struct {
-	oracleClient Oracle
-	authClient   Auth
-	txClient     TxService
-	keyBase      keyring.Keyring
-	txConfig     client.TxConfig
-	ir           codectypes.InterfaceRegistry
-	chainID      string
+	oracleClient   Oracle
+	authClient     Auth
+	txClient       TxService
+	nodeClient     Node
+	bankClient     Bank
+	feeGrantClient FeeGrant
+	keyBase        keyring.Keyring
+	txConfig       client.TxConfig
+	ir             codectypes.InterfaceRegistry
+	chainID        string
+	fees           FeeConfig
+	sequences      *sequenceManager
+	lastFees       *feeTracker
 }
 
 // Dial creates a new Client instance that connects to the blockchain.
 // It sets up all the required gRPC clients and dependencies for
 // transaction creation and submission.
+// A nil tlsConfig dials the gRPC endpoint without transport security.
 func Dial(
 	grpcEndpoint string,
 	chainID string,
-	enableTLS bool,
+	tlsConfig *tls.Config,
+	fees FeeConfig,
 	keyBase keyring.Keyring,
 	validator sdk.ValAddress,
 	feeder sdk.AccAddress,
 	logger zerolog.Logger,
 ) *Client {
-	var transportDialOpt grpc.DialOption
-
-	if enableTLS {
-		transportDialOpt = grpc.WithTransportCredentials(
-			credentials.NewTLS(
-				&tls.Config{
-					InsecureSkipVerify: false,
-				},
-			),
-		)
-	} else {
-		transportDialOpt = grpc.WithTransportCredentials(
-			insecure.NewCredentials(),
-		)
+	transportDialOpt := grpc.WithTransportCredentials(insecure.NewCredentials())
+	if tlsConfig != nil {
+		transportDialOpt = grpc.WithTransportCredentials(credentials.NewTLS(tlsConfig))
 	}
 
 	conn, err := grpc.Dial(grpcEndpoint, transportDialOpt)
@@ -85,14 +106,21 @@ func Dial(
 	}
 
 	encoding := app.MakeEncodingConfig()
+	authClient := authtypes.NewQueryClient(conn)
 	deps := deps{
-		oracleClient: oracletypes.NewQueryClient(conn),
-		authClient:   authtypes.NewQueryClient(conn),
-		txClient:     txservice.NewServiceClient(conn),
-		keyBase:      keyBase,
-		txConfig:     encoding.TxConfig,
-		ir:           encoding.InterfaceRegistry,
-		chainID:      chainID,
+		oracleClient:   oracletypes.NewQueryClient(conn),
+		authClient:     authClient,
+		txClient:       txservice.NewServiceClient(conn),
+		nodeClient:     node.NewServiceClient(conn),
+		bankClient:     banktypes.NewQueryClient(conn),
+		feeGrantClient: feegrant.NewQueryClient(conn),
+		keyBase:        keyBase,
+		txConfig:       encoding.TxConfig,
+		ir:             encoding.InterfaceRegistry,
+		chainID:        chainID,
+		fees:           fees,
+		sequences:      newSequenceManager(authClient, encoding.InterfaceRegistry, feeder),
+		lastFees:       new(feeTracker),
 	}
 
 	return &Client{
@@ -112,8 +140,98 @@ type Client struct {
 	validator sdk.ValAddress // Validator for which prices are being submitted
 	feeder    sdk.AccAddress // Feeder account that signs transactions
 
-	previousPrevote *prevote // Stores the previous prevote to create the reveal vote
-	deps            deps     // Dependencies for blockchain interaction
+	previousPrevote *prevote      // Stores the previous prevote to create the reveal vote
+	prevoteStore    *prevoteStore // Persists the previous prevote across restarts, nil if disabled
+	dryRun          *dryRun       // Records txs instead of broadcasting them, nil if disabled
+	deps            deps          // Dependencies for blockchain interaction
+
+	stopMonitors chan struct{}  // Signal to stop the background monitors
+	monitors     sync.WaitGroup // Background monitors started by the client
+}
+
+// LoadPrevoteState enables persisting the outstanding prevote to the given file,
+// and restores the prevote stored by a previous run so that it can be revealed
+// in the next voting period. A state file with invalid contents is discarded.
+func (c *Client) LoadPrevoteState(path string) error {
+	store := &prevoteStore{path: path}
+	p, height, err := store.load(c.validator, c.feeder)
+	switch {
+	case errors.Is(err, errInvalidPrevoteState):
+		c.logger.Warn().Err(err).Str("path", path).Msg("discarding stored prevote")
+	case err != nil:
+		return err
+	case p != nil:
+		c.logger.Info().Str("path", path).Uint64("height", height).Str("hash", p.msg.Hash).Msg("restored prevote")
+		c.previousPrevote = p
+	}
+
+	c.prevoteStore = store
+	return nil
+}
+
+// StartMissCounterMonitor checks every interval how many votes the validator
+// missed in the current slash window, until the client is closed.
+func (c *Client) StartMissCounterMonitor(interval time.Duration) {
+	m := &missCounterMonitor{
+		oracleClient: c.deps.oracleClient,
+		validator:    c.validator,
+		logger:       c.logger.With().Str("component", "miss-counter-monitor").Logger(),
+	}
+	c.startMonitor(interval, m.logger, m.check)
+}
+
+// StartBalanceMonitor checks every interval the balance of the account paying the fees,
+// the fee granter if any or the feeder, in the denoms of the gas prices, until the client is closed.
+func (c *Client) StartBalanceMonitor(interval time.Duration, thresholds BalanceThresholds) {
+	payer := c.feeder
+	if !c.deps.fees.Granter.Empty() {
+		payer = c.deps.fees.Granter
+	}
+	var feeDenoms []string
+	for _, price := range c.deps.fees.GasPrices {
+		feeDenoms = append(feeDenoms, price.Denom)
+	}
+	for _, coin := range thresholds.Warning.Add(thresholds.Critical...) {
+		if c.deps.fees.GasPrices.AmountOf(coin.Denom).IsZero() {
+			feeDenoms = append(feeDenoms, coin.Denom)
+		}
+	}
+
+	m := &balanceMonitor{
+		bankClient: c.deps.bankClient,
+		payer:      payer,
+		feeDenoms:  feeDenoms,
+		thresholds: thresholds,
+		lastFees:   c.deps.lastFees,
+		logger:     c.logger.With().Str("component", "balance-monitor").Logger(),
+	}
+	c.startMonitor(interval, m.logger, m.check)
+}
+
+// startMonitor calls check right away and then every interval until the client is closed.
+func (c *Client) startMonitor(interval time.Duration, logger zerolog.Logger, check func(context.Context) error) {
+	if c.stopMonitors == nil {
+		c.stopMonitors = make(chan struct{})
+	}
+	c.monitors.Add(1)
+	go func() {
+		defer c.monitors.Done()
+		tick := time.NewTicker(interval)
+		defer tick.Stop()
+		for {
+			ctx, cancel := context.WithTimeout(context.Background(), interval)
+			if err := check(ctx); err != nil {
+				logger.Err(err).Msg("check failed")
+			}
+			cancel()
+
+			select {
+			case <-c.stopMonitors:
+				return
+			case <-tick.C:
+			}
+		}
+	}()
 }
 
 // Whoami returns the validator address associated with this client
@@ -121,37 +239,66 @@ func (c *Client) Whoami() sdk.ValAddress {
 	return c.validator
 }
 
-// Prometheus metric for tracking price posting success/failure
-var pricePosterCounter = promauto.NewCounterVec(prometheus.CounterOpts{
-	Namespace: metrics.PrometheusNamespace,
-	Name:      "prices_posted_total",
-	Help:      "The total number of price update txs sent to the chain, by success status",
-}, []string{"success"})
-
 // SendPrices submits price data to the blockchain for the current voting period.
 // It follows the oracle module's two-phase commit process:
 // 1. Create a new prevote with price hashes (to prevent frontrunning)
 // 2. Reveal the previous prevote with actual prices
 // Both transactions are sent in a single broadcast if a previous prevote exists.
-func (c *Client) SendPrices(vp types.VotingPeriod, prices []types.Price) {
+// It then waits for the tx to be included in a block until the context is done,
+// which should be no later than the end of the voting period.
+func (c *Client) SendPrices(ctx context.Context, vp types.VotingPeriod, prices []types.Price) (types.PostResult, error) {
 	logger := c.logger.With().Uint64("voting-period-height", vp.Height).Logger()
 
-	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
-	defer cancel()
+	if c.dryRun != nil {
+		return c.sendPricesDryRun(ctx, vp, prices, logger)
+	}
 
 	newPrevote := newPrevote(prices, c.validator, c.feeder)
 	resp, err := vote(ctx, newPrevote, c.previousPrevote, c.validator, c.feeder, c.deps, logger)
-	if err != nil {
+	result := types.PostResult{Status: types.PostStatusFailed}
+	if resp != nil {
+		result.TxHash, result.Height = resp.TxHash, resp.Height
+	}
+	switch {
+	case errors.Is(err, errTxExpired):
+		// the tx might still be included later, in which case the new prevote is the one to reveal.
+		logger.Warn().Err(err).Msg("prevote not confirmed before the end of the voting period")
+		metrics.PostedPricesCounter.WithLabelValues(c.validator.String(), "false").Inc()
+		c.setPreviousPrevote(newPrevote, vp.Height, logger)
+		result.Status = types.PostStatusPending
+		return result, err
+	case err != nil:
 		logger.Err(err).Msg("prevote failed")
-		pricePosterCounter.WithLabelValues("false").Inc()
-		return
+		metrics.PostedPricesCounter.WithLabelValues(c.validator.String(), "false").Inc()
+		return result, err
 	}
 
-	c.previousPrevote = newPrevote
-	logger.Info().Str("tx-hash", resp.TxHash).Msg("successfully forwarded prices")
-	pricePosterCounter.WithLabelValues("true").Inc()
+	c.setPreviousPrevote(newPrevote, vp.Height, logger)
+	logger.Info().Str("tx-hash", resp.TxHash).Int64("block-height", resp.Height).Msg("successfully forwarded prices")
+	metrics.PostedPricesCounter.WithLabelValues(c.validator.String(), "true").Inc()
+	result.Status = types.PostStatusIncluded
+	return result, nil
+}
+
+// setPreviousPrevote records the prevote to reveal in the next voting period.
+func (c *Client) setPreviousPrevote(p *prevote, height uint64, logger zerolog.Logger) {
+	c.previousPrevote = p
+	if c.prevoteStore != nil {
+		if err := c.prevoteStore.save(p, height, c.validator); err != nil {
+			logger.Err(err).Msg("failed to persist prevote")
+		}
+	}
 }
 
 // Close cleans up any resources used by the client
 func (c *Client) Close() {
+	if c.stopMonitors != nil {
+		close(c.stopMonitors)
+		c.monitors.Wait()
+	}
+	if c.dryRun != nil {
+		if err := c.dryRun.file.Close(); err != nil {
+			c.logger.Err(err).Msg("failed to close dry run output")
+		}
+	}
 }
@@ -7,6 +7,7 @@ import (
 	"net/url"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/NibiruChain/nibiru/app"
 	testutilcli "github.com/NibiruChain/nibiru/x/common/testutil/cli"
@@ -53,11 +54,11 @@ func (s *IntegrationTestSuite) SetupSuite() {
 
 	s.logs = new(bytes.Buffer)
 
-	enableTLS := false
 	s.client = Dial(
 		grpcEndpoint,
 		s.cfg.ChainID,
-		enableTLS,
+		nil,
+		DefaultFeeConfig(),
 		val.ClientCtx.Keyring,
 		val.ValAddress,
 		val.Address,
@@ -70,7 +71,11 @@ func (s *IntegrationTestSuite) TearDownSuite() {
 }
 
 func (s *IntegrationTestSuite) TestClientWorks() {
-	s.client.SendPrices(types.VotingPeriod{}, s.randomPrices())
+	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+	defer cancel()
+	result, err := s.client.SendPrices(ctx, types.VotingPeriod{}, s.randomPrices())
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), types.PostStatusIncluded, result.Status)
 
 	// assert vote was skipped because no previous prevote
 	require.Contains(s.T(), s.logs.String(), "skipping vote preparation as there is no old prevote")
@@ -78,7 +83,10 @@ func (s *IntegrationTestSuite) TestClientWorks() {
 
 	// wait for next vote period
 	s.waitNextVotePeriod()
-	s.client.SendPrices(types.VotingPeriod{}, s.randomPrices())
+	ctx, cancel = context.WithTimeout(context.Background(), 15*time.Second)
+	defer cancel()
+	_, err = s.client.SendPrices(ctx, types.VotingPeriod{}, s.randomPrices())
+	require.NoError(s.T(), err)
 	require.Contains(s.T(), s.logs.String(), "prepared vote message")
 }
 
@@ -102,7 +110,8 @@ func (s *IntegrationTestSuite) waitNextVotePeriod() {
 	require.NoError(s.T(), err)
 	height, err := s.network.LatestHeight()
 	require.NoError(s.T(), err)
-	targetHeight := height + int64(uint64(height)%params.Params.VotePeriod)
+	votePeriod := int64(params.Params.VotePeriod)
+	targetHeight := height - height%votePeriod + votePeriod
 	_, err = s.network.WaitForHeight(targetHeight)
 	require.NoError(s.T(), err)
 }
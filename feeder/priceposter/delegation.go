@@ -0,0 +1,43 @@
+package priceposter
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	oracletypes "github.com/NibiruChain/nibiru/x/oracle/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// DelegateFeeder consents to the given account posting prices on behalf of the client's validator,
+// and waits for the tx to be included in a block. The tx is signed by the client's feeder account,
+// which must therefore be the validator's operator account, and pays its own fees.
+func (c *Client) DelegateFeeder(ctx context.Context, delegate sdk.AccAddress) (*sdk.TxResponse, error) {
+	msg := oracletypes.NewMsgDelegateFeedConsent(c.validator, delegate)
+
+	// the fee allowance is granted to the feeder, not to the operator
+	deps := c.deps
+	deps.fees = deps.fees.WithoutGranter()
+
+	sentAt := time.Now()
+	resp, err := sendTx(ctx, deps, c.feeder, c.logger, msg)
+	if err != nil {
+		return nil, err
+	}
+	return awaitInclusion(ctx, c.deps.txClient, c.validator, resp.TxHash, txTypeDelegateFeedConsent, sentAt, c.logger)
+}
+
+// CheckFeederDelegation returns an error unless the client's feeder
+// is the account allowed to post prices on behalf of its validator.
+func (c *Client) CheckFeederDelegation(ctx context.Context) error {
+	resp, err := c.deps.oracleClient.FeederDelegation(ctx, &oracletypes.QueryFeederDelegationRequest{
+		ValidatorAddr: c.validator.String(),
+	})
+	if err != nil {
+		return err
+	}
+	if resp.FeederAddr != c.feeder.String() {
+		return fmt.Errorf("validator %s delegated price feeding to %s, not to %s", c.validator, resp.FeederAddr, c.feeder)
+	}
+	return nil
+}
@@ -0,0 +1,88 @@
+package priceposter
+
+import (
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/NibiruChain/nibiru/app"
+	oracletypes "github.com/NibiruChain/nibiru/x/oracle/types"
+	"github.com/cosmos/cosmos-sdk/crypto/hd"
+	"github.com/cosmos/cosmos-sdk/crypto/keyring"
+	"github.com/cosmos/cosmos-sdk/crypto/keys/secp256k1"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	txservice "github.com/cosmos/cosmos-sdk/types/tx"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDelegateFeeder(t *testing.T) {
+	defer func(interval time.Duration) { InclusionPollInterval = interval }(InclusionPollInterval)
+	InclusionPollInterval = time.Millisecond
+
+	encoding := app.MakeEncodingConfig()
+	kb := keyring.NewInMemory(encoding.Marshaler)
+	record, _, err := kb.NewMnemonic("validator", keyring.English, sdk.FullFundraiserPath, "", hd.Secp256k1)
+	require.NoError(t, err)
+	operator, err := record.GetAddress()
+	require.NoError(t, err)
+	validator := sdk.ValAddress(operator)
+	delegate := sdk.AccAddress(secp256k1.GenPrivKey().PubKey().Address())
+
+	var broadcast sdk.Tx
+	txClient := mockTxService{
+		simulate: func(*txservice.SimulateRequest) (*txservice.SimulateResponse, error) {
+			return &txservice.SimulateResponse{GasInfo: &sdk.GasInfo{GasUsed: 10_000}}, nil
+		},
+		broadcastTx: func(req *txservice.BroadcastTxRequest) (*txservice.BroadcastTxResponse, error) {
+			broadcast, err = encoding.TxConfig.TxDecoder()(req.TxBytes)
+			require.NoError(t, err)
+			return &txservice.BroadcastTxResponse{TxResponse: &sdk.TxResponse{TxHash: "ABCD"}}, nil
+		},
+		getTx: func(req *txservice.GetTxRequest) (*txservice.GetTxResponse, error) {
+			return &txservice.GetTxResponse{TxResponse: &sdk.TxResponse{TxHash: req.Hash, Height: 10}}, nil
+		},
+	}
+	// the fee config of the feeder, whose fees are paid by a granter
+	fees := DefaultFeeConfig()
+	fees.Granter = sdk.AccAddress(secp256k1.GenPrivKey().PubKey().Address())
+	authClient := &mockAuth{account: authtypes.NewBaseAccount(operator, nil, 1, 5)}
+	c := &Client{
+		logger:    zerolog.New(io.Discard),
+		validator: validator,
+		feeder:    operator,
+		deps: deps{
+			authClient: authClient,
+			txClient:   txClient,
+			nodeClient: mockNode{},
+			keyBase:    kb,
+			txConfig:   encoding.TxConfig,
+			ir:         encoding.InterfaceRegistry,
+			chainID:    "test-1",
+			fees:       fees,
+			sequences:  newSequenceManager(authClient, encoding.InterfaceRegistry, operator),
+		},
+	}
+
+	resp, err := c.DelegateFeeder(context.Background(), delegate)
+	require.NoError(t, err)
+	require.Equal(t, "ABCD", resp.TxHash)
+
+	require.Equal(t, []sdk.Msg{oracletypes.NewMsgDelegateFeedConsent(validator, delegate)}, broadcast.GetMsgs())
+	require.Empty(t, broadcast.(sdk.FeeTx).FeeGranter())
+}
+
+func TestCheckFeederDelegation(t *testing.T) {
+	feeder := sdk.AccAddress(secp256k1.GenPrivKey().PubKey().Address())
+	validator := sdk.ValAddress(secp256k1.GenPrivKey().PubKey().Address())
+	oracle := &mockOracle{feeder: feeder}
+	c := &Client{logger: zerolog.New(io.Discard), validator: validator, feeder: feeder, deps: deps{oracleClient: oracle}}
+
+	require.NoError(t, c.CheckFeederDelegation(context.Background()))
+
+	// without a delegation the chain returns the validator's own account
+	oracle.feeder = sdk.AccAddress(validator)
+	require.ErrorContains(t, c.CheckFeederDelegation(context.Background()), "delegated price feeding to")
+}
@@ -0,0 +1,112 @@
+package priceposter
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"os"
+	"time"
+
+	"github.com/NibiruChain/pricefeeder/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/rs/zerolog"
+)
+
+// dryRun writes the txs the Client would broadcast to a file instead of broadcasting them.
+type dryRun struct {
+	file     *os.File
+	simulate bool
+}
+
+// dryRunRecord is a line of the dry run output.
+type dryRunRecord struct {
+	Time               time.Time `json:"time"`
+	VotingPeriodHeight uint64    `json:"voting_period_height"`
+	Validator          string    `json:"validator"`
+	Feeder             string    `json:"feeder"`
+	PrevoteHash        string    `json:"prevote_hash"`
+	PrevoteSalt        string    `json:"prevote_salt"`
+	PrevoteVote        string    `json:"prevote_vote"`
+	VoteSalt           string    `json:"vote_salt,omitempty"`
+	Vote               string    `json:"vote,omitempty"`
+	GasLimit           uint64    `json:"gas_limit,omitempty"`
+	Fees               string    `json:"fees,omitempty"`
+	SimulationError    string    `json:"simulation_error,omitempty"`
+	Tx                 string    `json:"tx"`
+}
+
+// EnableDryRun makes SendPrices build and sign txs without ever broadcasting them.
+// Each tx is appended as a JSON line to the given file, along with the vote strings
+// it prevotes and reveals. If simulate is true, txs are simulated against the node
+// to report their gas limit and fees.
+func (c *Client) EnableDryRun(path string, simulate bool) error {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return err
+	}
+	c.dryRun = &dryRun{file: file, simulate: simulate}
+	return nil
+}
+
+// sendPricesDryRun writes the tx SendPrices would broadcast to the dry run output.
+// The prevote is only kept in memory, so the next tx reveals it as if it was on chain.
+func (c *Client) sendPricesDryRun(ctx context.Context, vp types.VotingPeriod, prices []types.Price, logger zerolog.Logger) (types.PostResult, error) {
+	newPrevote := newPrevote(prices, c.validator, c.feeder)
+	record := dryRunRecord{
+		Time:               time.Now(),
+		VotingPeriodHeight: vp.Height,
+		Validator:          c.validator.String(),
+		Feeder:             c.feeder.String(),
+		PrevoteHash:        newPrevote.msg.Hash,
+		PrevoteSalt:        newPrevote.salt,
+		PrevoteVote:        newPrevote.vote,
+	}
+
+	msgs := []sdk.Msg{newPrevote.msg}
+	if c.previousPrevote != nil {
+		msgs = []sdk.Msg{newVoteMsg(c.previousPrevote, c.validator, c.feeder), newPrevote.msg}
+		record.VoteSalt = c.previousPrevote.salt
+		record.Vote = c.previousPrevote.vote
+	}
+	txBuilder, keyName, pubKey := newTxBuilder(c.deps, c.feeder, msgs...)
+
+	accNum, sequence, err := getAccount(ctx, c.deps.authClient, c.deps.ir, c.feeder)
+	if err != nil {
+		logger.Err(err).Msg("dry run: failed to get feeder account")
+		return types.PostResult{Status: types.PostStatusFailed}, err
+	}
+	if c.dryRun.simulate {
+		// the reveal fails to simulate if the chain has another prevote for the validator
+		if err := setFees(ctx, c.deps, txBuilder, pubKey, sequence, 0, logger); err != nil {
+			logger.Warn().Err(err).Msg("dry run: failed to simulate tx")
+			record.SimulationError = err.Error()
+		}
+	}
+
+	txBytes, err := signTx(c.deps, txBuilder, keyName, accNum, sequence)
+	if err != nil {
+		logger.Err(err).Msg("dry run: failed to sign tx")
+		return types.PostResult{Status: types.PostStatusFailed}, err
+	}
+	record.GasLimit = txBuilder.GetTx().GetGas()
+	record.Fees = txBuilder.GetTx().GetFee().String()
+	record.Tx = base64.StdEncoding.EncodeToString(txBytes)
+
+	b, err := json.Marshal(record)
+	if err != nil {
+		panic(err)
+	}
+	if _, err := c.dryRun.file.Write(append(b, '\n')); err != nil {
+		logger.Err(err).Msg("dry run: failed to write tx")
+		return types.PostResult{Status: types.PostStatusFailed}, err
+	}
+
+	c.previousPrevote = newPrevote
+	logger.Info().
+		Str("prevote", record.PrevoteVote).
+		Str("vote", record.Vote).
+		Uint64("gas-limit", record.GasLimit).
+		Str("fees", record.Fees).
+		Msg("dry run: recorded tx instead of broadcasting it")
+	return types.PostResult{Status: types.PostStatusDryRun}, nil
+}
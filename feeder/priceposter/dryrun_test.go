@@ -0,0 +1,103 @@
+package priceposter
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/NibiruChain/nibiru/app"
+	"github.com/NibiruChain/nibiru/x/common/asset"
+	"github.com/NibiruChain/nibiru/x/common/denoms"
+	oracletypes "github.com/NibiruChain/nibiru/x/oracle/types"
+	"github.com/NibiruChain/pricefeeder/types"
+	"github.com/cosmos/cosmos-sdk/crypto/hd"
+	"github.com/cosmos/cosmos-sdk/crypto/keyring"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	txservice "github.com/cosmos/cosmos-sdk/types/tx"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDryRun(t *testing.T) {
+	// the prefixes can only be set once, and are already if the integration test ran first
+	if sdk.GetConfig().GetBech32AccountAddrPrefix() != app.AccountAddressPrefix {
+		app.SetPrefixes(app.AccountAddressPrefix)
+	}
+	encoding := app.MakeEncodingConfig()
+	kb := keyring.NewInMemory(encoding.Marshaler)
+	record, _, err := kb.NewMnemonic("feeder", keyring.English, sdk.FullFundraiserPath, "", hd.Secp256k1)
+	require.NoError(t, err)
+	feeder, err := record.GetAddress()
+	require.NoError(t, err)
+	validator := sdk.ValAddress(feeder)
+
+	txClient := mockTxService{
+		simulate: func(*txservice.SimulateRequest) (*txservice.SimulateResponse, error) {
+			return &txservice.SimulateResponse{GasInfo: &sdk.GasInfo{GasUsed: 10_000}}, nil
+		},
+		broadcastTx: func(*txservice.BroadcastTxRequest) (*txservice.BroadcastTxResponse, error) {
+			t.Fatal("dry run must not broadcast")
+			return nil, nil
+		},
+	}
+	c := &Client{
+		logger:    zerolog.New(io.Discard),
+		validator: validator,
+		feeder:    feeder,
+		deps: deps{
+			authClient: &mockAuth{account: authtypes.NewBaseAccount(feeder, nil, 1, 5)},
+			txClient:   txClient,
+			nodeClient: mockNode{},
+			keyBase:    kb,
+			txConfig:   encoding.TxConfig,
+			ir:         encoding.InterfaceRegistry,
+			chainID:    "test-1",
+			fees:       DefaultFeeConfig(),
+		},
+	}
+
+	path := filepath.Join(t.TempDir(), "dryrun.jsonl")
+	require.NoError(t, c.EnableDryRun(path, true))
+
+	prices := []types.Price{{Pair: asset.Registry.Pair(denoms.BTC, denoms.NUSD), Price: 27_000.5, Valid: true}}
+	for _, height := range []uint64{100, 110} {
+		result, err := c.SendPrices(context.Background(), types.VotingPeriod{Height: height}, prices)
+		require.NoError(t, err)
+		require.Equal(t, types.PostResult{Status: types.PostStatusDryRun}, result)
+	}
+	c.Close()
+
+	f, err := os.Open(path)
+	require.NoError(t, err)
+	defer f.Close()
+	var records []dryRunRecord
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var r dryRunRecord
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &r))
+		records = append(records, r)
+	}
+	require.Len(t, records, 2)
+
+	first, second := records[0], records[1]
+	require.Equal(t, uint64(100), first.VotingPeriodHeight)
+	require.Empty(t, first.Vote)
+	require.Equal(t, "(ubtc:unusd,27000.500000000000000000)", first.PrevoteVote)
+	require.Equal(t, uint64(15_000), first.GasLimit)
+	require.Equal(t, "375unibi", first.Fees)
+	require.NotEmpty(t, first.Tx)
+
+	// the second tx reveals the first prevote
+	require.Equal(t, first.PrevoteSalt, second.VoteSalt)
+	require.Equal(t, first.PrevoteVote, second.Vote)
+	require.NoError(t, verifyPrevote(&prevote{
+		msg:  &oracletypes.MsgAggregateExchangeRatePrevote{Hash: first.PrevoteHash},
+		salt: second.VoteSalt,
+		vote: second.Vote,
+	}, validator))
+}
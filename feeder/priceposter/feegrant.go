@@ -0,0 +1,108 @@
+package priceposter
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	oracletypes "github.com/NibiruChain/nibiru/x/oracle/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/feegrant"
+)
+
+var (
+	// EstimatedVoteGas is the gas a vote is assumed to use when estimating the spend of the feeder,
+	// before the gas adjustment. Oracle txs run on a fixed gas meter, so it rarely changes.
+	EstimatedVoteGas uint64 = 500
+	// FeeGrantMinVotes is how many votes the fee allowance is expected to cover at startup.
+	FeeGrantMinVotes uint64 = 1_000
+)
+
+// feeGrantMsgs are the msgs the fee allowance must allow.
+var feeGrantMsgs = []sdk.Msg{
+	&oracletypes.MsgAggregateExchangeRatePrevote{},
+	&oracletypes.MsgAggregateExchangeRateVote{},
+}
+
+// CheckFeeGrant returns an error unless the fee granter gave the feeder an allowance
+// which has not expired and allows the oracle msgs. It only logs a warning if the
+// allowance does not cover the estimated fees of FeeGrantMinVotes votes.
+func (c *Client) CheckFeeGrant(ctx context.Context) error {
+	granter := c.deps.fees.Granter
+	resp, err := c.deps.feeGrantClient.Allowance(ctx, &feegrant.QueryAllowanceRequest{
+		Granter: granter.String(),
+		Grantee: c.feeder.String(),
+	})
+	if err != nil {
+		return fmt.Errorf("no fee allowance from %s to %s: %w", granter, c.feeder, err)
+	}
+
+	var allowance feegrant.FeeAllowanceI
+	if err := c.deps.ir.UnpackAny(resp.Allowance.Allowance, &allowance); err != nil {
+		return err
+	}
+
+	expiration, err := allowance.ExpiresAt()
+	if err != nil {
+		return err
+	}
+	if expiration != nil && !expiration.After(time.Now()) {
+		return fmt.Errorf("fee allowance from %s expired at %s", granter, expiration)
+	}
+
+	spendLimits, allowedMsgs, err := allowanceLimits(allowance)
+	if err != nil {
+		return err
+	}
+	if allowedMsgs != nil {
+		for _, msg := range feeGrantMsgs {
+			if !contains(allowedMsgs, sdk.MsgTypeURL(msg)) {
+				return fmt.Errorf("fee allowance from %s does not allow %s", granter, sdk.MsgTypeURL(msg))
+			}
+		}
+	}
+
+	gasPrices := gasPrices(ctx, c.deps.nodeClient, c.deps.fees, c.logger)
+	spend := computeFees(gasPrices, adjustedGasLimit(EstimatedVoteGas, c.deps.fees.GasAdjustment)*FeeGrantMinVotes)
+	for _, limit := range spendLimits {
+		if !limit.Empty() && !spend.IsAllLTE(limit) {
+			c.logger.Warn().
+				Str("granter", granter.String()).
+				Str("spend-limit", limit.String()).
+				Str("estimated-spend", spend.String()).
+				Uint64("votes", FeeGrantMinVotes).
+				Msg("fee allowance might not cover the fees of the next votes")
+			break
+		}
+	}
+	return nil
+}
+
+// allowanceLimits returns the spend limits of the allowance, empty ones meaning no limit,
+// and the msgs it is restricted to, nil if it is not restricted.
+func allowanceLimits(allowance feegrant.FeeAllowanceI) ([]sdk.Coins, []string, error) {
+	switch a := allowance.(type) {
+	case *feegrant.BasicAllowance:
+		return []sdk.Coins{a.SpendLimit}, nil, nil
+	case *feegrant.PeriodicAllowance:
+		return []sdk.Coins{a.Basic.SpendLimit, a.PeriodSpendLimit}, nil, nil
+	case *feegrant.AllowedMsgAllowance:
+		inner, err := a.GetAllowance()
+		if err != nil {
+			return nil, nil, err
+		}
+		limits, _, err := allowanceLimits(inner)
+		return limits, a.AllowedMessages, err
+	default:
+		return nil, nil, fmt.Errorf("unsupported fee allowance %T", allowance)
+	}
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,116 @@
+package priceposter
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/NibiruChain/nibiru/app"
+	oracletypes "github.com/NibiruChain/nibiru/x/oracle/types"
+	"github.com/cosmos/cosmos-sdk/crypto/keys/secp256k1"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	"github.com/cosmos/cosmos-sdk/x/feegrant"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+)
+
+type mockFeeGrant struct {
+	grant *feegrant.Grant
+}
+
+func (m mockFeeGrant) Allowance(context.Context, *feegrant.QueryAllowanceRequest, ...grpc.CallOption) (*feegrant.QueryAllowanceResponse, error) {
+	if m.grant == nil {
+		return nil, feegrant.ErrNoAllowance
+	}
+	return &feegrant.QueryAllowanceResponse{Allowance: m.grant}, nil
+}
+
+func TestCheckFeeGrant(t *testing.T) {
+	granter := sdk.AccAddress(secp256k1.GenPrivKey().PubKey().Address())
+	feeder := sdk.AccAddress(secp256k1.GenPrivKey().PubKey().Address())
+	expired := time.Now().Add(-time.Hour)
+
+	newClient := func(allowance feegrant.FeeAllowanceI) *Client {
+		grantClient := mockFeeGrant{}
+		if allowance != nil {
+			grant, err := feegrant.NewGrant(granter, feeder, allowance)
+			require.NoError(t, err)
+			grantClient.grant = &grant
+		}
+		fees := DefaultFeeConfig()
+		fees.Granter = granter
+		return &Client{
+			logger: zerolog.New(io.Discard),
+			feeder: feeder,
+			deps: deps{
+				feeGrantClient: grantClient,
+				ir:             app.MakeEncodingConfig().InterfaceRegistry,
+				fees:           fees,
+			},
+		}
+	}
+
+	t.Run("valid allowance", func(t *testing.T) {
+		require.NoError(t, newClient(&feegrant.BasicAllowance{}).CheckFeeGrant(context.Background()))
+	})
+
+	t.Run("no allowance", func(t *testing.T) {
+		require.ErrorContains(t, newClient(nil).CheckFeeGrant(context.Background()), "no fee allowance")
+	})
+
+	t.Run("expired allowance", func(t *testing.T) {
+		err := newClient(&feegrant.BasicAllowance{Expiration: &expired}).CheckFeeGrant(context.Background())
+		require.ErrorContains(t, err, "expired")
+	})
+
+	t.Run("allowance restricted to other msgs", func(t *testing.T) {
+		allowance, err := feegrant.NewAllowedMsgAllowance(&feegrant.BasicAllowance{}, []string{
+			sdk.MsgTypeURL(&oracletypes.MsgAggregateExchangeRatePrevote{}),
+			sdk.MsgTypeURL(&banktypes.MsgSend{}),
+		})
+		require.NoError(t, err)
+		require.ErrorContains(t, newClient(allowance).CheckFeeGrant(context.Background()), "does not allow")
+
+		allowance, err = feegrant.NewAllowedMsgAllowance(&feegrant.BasicAllowance{}, []string{
+			sdk.MsgTypeURL(&oracletypes.MsgAggregateExchangeRatePrevote{}),
+			sdk.MsgTypeURL(&oracletypes.MsgAggregateExchangeRateVote{}),
+		})
+		require.NoError(t, err)
+		require.NoError(t, newClient(allowance).CheckFeeGrant(context.Background()))
+	})
+
+	t.Run("allowance below the estimated spend", func(t *testing.T) {
+		// the default gas prices over minGasLimit gas per vote
+		spend := int64(FeeGrantMinVotes) * 125
+		for _, tc := range []struct {
+			spendLimit int64
+			warns      bool
+		}{
+			{spendLimit: spend, warns: false},
+			{spendLimit: spend - 1, warns: true},
+		} {
+			var logs bytes.Buffer
+			c := newClient(&feegrant.BasicAllowance{SpendLimit: sdk.NewCoins(sdk.NewInt64Coin("unibi", tc.spendLimit))})
+			c.logger = zerolog.New(&logs)
+			require.NoError(t, c.CheckFeeGrant(context.Background()))
+			require.Equal(t, tc.warns, bytes.Contains(logs.Bytes(), []byte("might not cover")), "spend limit %d", tc.spendLimit)
+		}
+	})
+}
+
+func TestAllowanceLimits(t *testing.T) {
+	limit := sdk.NewCoins(sdk.NewInt64Coin("unibi", 1_000))
+	periodLimit := sdk.NewCoins(sdk.NewInt64Coin("unibi", 100))
+
+	limits, msgs, err := allowanceLimits(&feegrant.PeriodicAllowance{
+		Basic:            feegrant.BasicAllowance{SpendLimit: limit},
+		PeriodSpendLimit: periodLimit,
+	})
+	require.NoError(t, err)
+	require.Nil(t, msgs)
+	require.Equal(t, []sdk.Coins{limit, periodLimit}, limits)
+}
@@ -0,0 +1,175 @@
+package priceposter
+
+import (
+	"context"
+	"fmt"
+	"math"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/client/grpc/node"
+	cryptotypes "github.com/cosmos/cosmos-sdk/crypto/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	txservice "github.com/cosmos/cosmos-sdk/types/tx"
+	"github.com/cosmos/cosmos-sdk/types/tx/signing"
+	"github.com/rs/zerolog"
+)
+
+const (
+	// DefaultGasAdjustment is the default multiplier applied to the simulated gas.
+	DefaultGasAdjustment = 1.5
+	// DefaultGasPrices are the default prices paid per unit of gas.
+	DefaultGasPrices = "0.025unibi"
+)
+
+// minGasLimit is the lowest gas limit set on a tx. Oracle txs run on a fixed gas meter, so their
+// simulated gas leaves out the gas consumed by the ante handlers before it, which the limit must cover.
+const minGasLimit = 5_000
+
+// FeeConfig configures how the gas limit and the fees
+// of the feeder's transactions are computed.
+type FeeConfig struct {
+	// GasAdjustment multiplies the gas used by the simulated tx to obtain the gas limit.
+	GasAdjustment float64
+	// GasPrices are the prices paid per unit of gas.
+	GasPrices sdk.DecCoins
+	// UseNodeMinGasPrices makes the node's minimum gas prices
+	// override GasPrices whenever they are higher.
+	UseNodeMinGasPrices bool
+	// MaxFee is the fee budget of a single tx, txs whose fees
+	// exceed it are not sent. Empty means no ceiling.
+	MaxFee sdk.Coins
+	// Granter pays the fees through an x/feegrant allowance to the feeder.
+	// Empty means the feeder pays its own fees.
+	Granter sdk.AccAddress
+}
+
+// DefaultFeeConfig returns the FeeConfig used when none is configured.
+func DefaultFeeConfig() FeeConfig {
+	gasPrices, err := sdk.ParseDecCoins(DefaultGasPrices)
+	if err != nil {
+		panic(err)
+	}
+	return FeeConfig{
+		GasAdjustment: DefaultGasAdjustment,
+		GasPrices:     gasPrices,
+	}
+}
+
+// WithoutGranter returns a copy of the FeeConfig whose fees are paid by the signer of the tx,
+// for txs signed by another account than the one the fee allowance was granted to.
+func (c FeeConfig) WithoutGranter() FeeConfig {
+	c.Granter = nil
+	return c
+}
+
+// Validate returns an error if the FeeConfig cannot be used to compute fees.
+func (c FeeConfig) Validate() error {
+	if c.GasAdjustment < 1 {
+		return fmt.Errorf("gas adjustment must be at least 1, got %f", c.GasAdjustment)
+	}
+	if c.GasPrices.IsZero() && !c.UseNodeMinGasPrices {
+		return fmt.Errorf("no gas prices configured")
+	}
+	return nil
+}
+
+// simulateGas estimates the gas used by the tx being built by simulating it
+// against the node, and returns the gas limit adjusted by the given multiplier, at least minGasLimit.
+func simulateGas(
+	ctx context.Context,
+	txClient TxService,
+	txConfig client.TxConfig,
+	txBuilder client.TxBuilder,
+	pubKey cryptotypes.PubKey,
+	sequence uint64,
+	gasAdjustment float64,
+) (uint64, error) {
+	// the ante handler does not verify signatures when simulating,
+	// an empty one is enough to account for the signature's gas.
+	err := txBuilder.SetSignatures(signing.SignatureV2{
+		PubKey:   pubKey,
+		Data:     &signing.SingleSignatureData{SignMode: txConfig.SignModeHandler().DefaultMode()},
+		Sequence: sequence,
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	txBytes, err := txConfig.TxEncoder()(txBuilder.GetTx())
+	if err != nil {
+		return 0, err
+	}
+
+	resp, err := txClient.Simulate(ctx, &txservice.SimulateRequest{TxBytes: txBytes})
+	if err != nil {
+		return 0, fmt.Errorf("failed to simulate tx: %w", err)
+	}
+
+	return adjustedGasLimit(resp.GasInfo.GasUsed, gasAdjustment), nil
+}
+
+// adjustedGasLimit returns the gas limit of a tx using the given gas, adjusted by the given multiplier, at least minGasLimit.
+func adjustedGasLimit(gasUsed uint64, gasAdjustment float64) uint64 {
+	limit := uint64(math.Ceil(float64(gasUsed) * gasAdjustment))
+	if limit < minGasLimit {
+		return minGasLimit
+	}
+	return limit
+}
+
+// gasPrices returns the gas prices to pay, taking into account the node's
+// minimum gas prices if required. If the node cannot be queried the
+// configured gas prices are used.
+func gasPrices(ctx context.Context, nodeClient Node, config FeeConfig, logger zerolog.Logger) sdk.DecCoins {
+	if !config.UseNodeMinGasPrices {
+		return config.GasPrices
+	}
+
+	resp, err := nodeClient.Config(ctx, &node.ConfigRequest{})
+	if err != nil {
+		logger.Err(err).Msg("failed to query node minimum gas prices, using configured gas prices")
+		return config.GasPrices
+	}
+	minGasPrices, err := sdk.ParseDecCoins(resp.MinimumGasPrice)
+	if err != nil {
+		logger.Err(err).Str("min-gas-prices", resp.MinimumGasPrice).Msg("invalid node minimum gas prices, using configured gas prices")
+		return config.GasPrices
+	}
+
+	return maxGasPrices(config.GasPrices, minGasPrices)
+}
+
+// maxGasPrices returns, for every denom in either of the given gas prices, the highest price.
+func maxGasPrices(a, b sdk.DecCoins) sdk.DecCoins {
+	prices := sdk.NewDecCoins()
+	for _, price := range a {
+		if other := b.AmountOf(price.Denom); other.GT(price.Amount) {
+			price.Amount = other
+		}
+		prices = prices.Add(price)
+	}
+	for _, price := range b {
+		if a.AmountOf(price.Denom).IsZero() {
+			prices = prices.Add(price)
+		}
+	}
+	return prices
+}
+
+// computeFees returns the fees to pay for the given gas limit, fee = ceil(gasPrice * gasLimit).
+func computeFees(prices sdk.DecCoins, gasLimit uint64) sdk.Coins {
+	gas := sdk.NewDecFromInt(sdk.NewIntFromUint64(gasLimit))
+	fees := sdk.NewCoins()
+	for _, price := range prices {
+		fees = fees.Add(sdk.NewCoin(price.Denom, price.Amount.Mul(gas).Ceil().RoundInt()))
+	}
+	return fees
+}
+
+// checkFeeBudget returns an error if the fees exceed the configured ceiling.
+func checkFeeBudget(fees sdk.Coins, maxFee sdk.Coins) error {
+	if maxFee.Empty() || fees.IsAllLTE(maxFee) {
+		return nil
+	}
+	return fmt.Errorf("tx fees %s exceed the fee budget %s", fees, maxFee)
+}
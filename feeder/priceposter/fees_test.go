@@ -0,0 +1,132 @@
+package priceposter
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"testing"
+
+	"github.com/NibiruChain/nibiru/app"
+	"github.com/cosmos/cosmos-sdk/client/grpc/node"
+	"github.com/cosmos/cosmos-sdk/crypto/keys/secp256k1"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	txservice "github.com/cosmos/cosmos-sdk/types/tx"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+)
+
+type mockTxService struct {
+	simulate    func(*txservice.SimulateRequest) (*txservice.SimulateResponse, error)
+	broadcastTx func(*txservice.BroadcastTxRequest) (*txservice.BroadcastTxResponse, error)
+	getTx       func(*txservice.GetTxRequest) (*txservice.GetTxResponse, error)
+}
+
+func (m mockTxService) BroadcastTx(_ context.Context, req *txservice.BroadcastTxRequest, _ ...grpc.CallOption) (*txservice.BroadcastTxResponse, error) {
+	return m.broadcastTx(req)
+}
+
+func (m mockTxService) GetTx(_ context.Context, req *txservice.GetTxRequest, _ ...grpc.CallOption) (*txservice.GetTxResponse, error) {
+	return m.getTx(req)
+}
+
+func (m mockTxService) Simulate(_ context.Context, req *txservice.SimulateRequest, _ ...grpc.CallOption) (*txservice.SimulateResponse, error) {
+	return m.simulate(req)
+}
+
+type mockNode struct {
+	minGasPrice string
+	err         error
+}
+
+func (m mockNode) Config(context.Context, *node.ConfigRequest, ...grpc.CallOption) (*node.ConfigResponse, error) {
+	return &node.ConfigResponse{MinimumGasPrice: m.minGasPrice}, m.err
+}
+
+func mustDecCoins(t *testing.T, s string) sdk.DecCoins {
+	coins, err := sdk.ParseDecCoins(s)
+	require.NoError(t, err)
+	return coins
+}
+
+func TestFeeConfig_Validate(t *testing.T) {
+	require.NoError(t, DefaultFeeConfig().Validate())
+	require.Error(t, FeeConfig{GasAdjustment: 0.5, GasPrices: DefaultFeeConfig().GasPrices}.Validate())
+	require.Error(t, FeeConfig{GasAdjustment: 1}.Validate())
+	require.NoError(t, FeeConfig{GasAdjustment: 1, UseNodeMinGasPrices: true}.Validate())
+}
+
+func TestComputeFees(t *testing.T) {
+	// the old hardcoded values: 5_000 gas at 0.025unibi
+	require.Equal(t, "125unibi", computeFees(mustDecCoins(t, "0.025unibi"), 5_000).String())
+	// fees are rounded up
+	require.Equal(t, "1unibi,3uusd", computeFees(mustDecCoins(t, "0.001unibi,0.25uusd"), 10).String())
+	require.True(t, computeFees(nil, 5_000).Empty())
+}
+
+func TestGasPrices(t *testing.T) {
+	logger := zerolog.New(io.Discard)
+	config := FeeConfig{GasAdjustment: 1, GasPrices: mustDecCoins(t, "0.025unibi,0.1uusd")}
+
+	t.Run("node prices disabled", func(t *testing.T) {
+		prices := gasPrices(context.Background(), mockNode{minGasPrice: "1unibi"}, config, logger)
+		require.Equal(t, config.GasPrices, prices)
+	})
+
+	config.UseNodeMinGasPrices = true
+	t.Run("highest price wins", func(t *testing.T) {
+		prices := gasPrices(context.Background(), mockNode{minGasPrice: "0.05unibi,0.01uusd,0.5ufoo"}, config, logger)
+		require.Equal(t, mustDecCoins(t, "0.05unibi,0.1uusd,0.5ufoo"), prices)
+	})
+
+	t.Run("node query fails", func(t *testing.T) {
+		prices := gasPrices(context.Background(), mockNode{err: fmt.Errorf("unavailable")}, config, logger)
+		require.Equal(t, config.GasPrices, prices)
+	})
+
+	t.Run("node prices empty", func(t *testing.T) {
+		prices := gasPrices(context.Background(), mockNode{}, config, logger)
+		require.Equal(t, config.GasPrices, prices)
+	})
+}
+
+func TestCheckFeeBudget(t *testing.T) {
+	fees := sdk.NewCoins(sdk.NewInt64Coin("unibi", 200))
+	require.NoError(t, checkFeeBudget(fees, nil))
+	require.NoError(t, checkFeeBudget(fees, sdk.NewCoins(sdk.NewInt64Coin("unibi", 200))))
+	require.ErrorContains(t, checkFeeBudget(fees, sdk.NewCoins(sdk.NewInt64Coin("unibi", 199))), "exceed the fee budget")
+	require.Error(t, checkFeeBudget(fees, sdk.NewCoins(sdk.NewInt64Coin("uusd", 1000))))
+}
+
+func TestSimulateGas(t *testing.T) {
+	encoding := app.MakeEncodingConfig()
+	key := secp256k1.GenPrivKey()
+	addr := sdk.AccAddress(key.PubKey().Address())
+
+	txBuilder := encoding.TxConfig.NewTxBuilder()
+	require.NoError(t, txBuilder.SetMsgs(banktypes.NewMsgSend(addr, addr, sdk.NewCoins(sdk.NewInt64Coin("unibi", 1)))))
+
+	gasUsed := uint64(10_001)
+	txClient := mockTxService{simulate: func(req *txservice.SimulateRequest) (*txservice.SimulateResponse, error) {
+		tx, err := encoding.TxConfig.TxDecoder()(req.TxBytes)
+		require.NoError(t, err)
+		require.Len(t, tx.GetMsgs(), 1)
+		return &txservice.SimulateResponse{GasInfo: &sdk.GasInfo{GasUsed: gasUsed}}, nil
+	}}
+	gas, err := simulateGas(context.Background(), txClient, encoding.TxConfig, txBuilder, key.PubKey(), 3, 1.5)
+	require.NoError(t, err)
+	require.Equal(t, uint64(15_002), gas)
+
+	// the fixed gas of oracle txs is below the gas consumed by the ante handlers
+	gasUsed = 500
+	gas, err = simulateGas(context.Background(), txClient, encoding.TxConfig, txBuilder, key.PubKey(), 3, 1.5)
+	require.NoError(t, err)
+	require.Equal(t, uint64(minGasLimit), gas)
+
+	txClient.simulate = func(*txservice.SimulateRequest) (*txservice.SimulateResponse, error) {
+		return nil, fmt.Errorf("out of gas")
+	}
+	_, err = simulateGas(context.Background(), txClient, encoding.TxConfig, txBuilder, key.PubKey(), 3, 1.5)
+	require.ErrorContains(t, err, "failed to simulate tx")
+}
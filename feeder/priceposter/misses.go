@@ -0,0 +1,76 @@
+package priceposter
+
+import (
+	"context"
+
+	oracletypes "github.com/NibiruChain/nibiru/x/oracle/types"
+	"github.com/NibiruChain/pricefeeder/metrics"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/rs/zerolog"
+)
+
+// missCounterMonitor tracks the votes missed by the validator in the current slash window,
+// warning louder and louder as they approach the number of misses that gets it slashed.
+type missCounterMonitor struct {
+	oracleClient Oracle
+	validator    sdk.ValAddress
+	logger       zerolog.Logger
+
+	lastMissCounter uint64
+}
+
+// allowedMisses returns how many vote periods a validator can miss in a slash window
+// without being slashed: the oracle module slashes validators whose valid vote rate
+// (votePeriodsPerWindow - missCounter) / votePeriodsPerWindow is below MinValidPerWindow.
+func allowedMisses(params oracletypes.Params) uint64 {
+	if params.VotePeriod == 0 {
+		return 0
+	}
+	votePeriodsPerWindow := int64(params.SlashWindow / params.VotePeriod)
+	return uint64(sdk.OneDec().Sub(params.MinValidPerWindow).MulInt64(votePeriodsPerWindow).TruncateInt64())
+}
+
+// check queries the miss counter and the aggregate vote of the validator and reports them.
+func (m *missCounterMonitor) check(ctx context.Context) error {
+	paramsResp, err := m.oracleClient.Params(ctx, &oracletypes.QueryParamsRequest{})
+	if err != nil {
+		return err
+	}
+	missResp, err := m.oracleClient.MissCounter(ctx, &oracletypes.QueryMissCounterRequest{ValidatorAddr: m.validator.String()})
+	if err != nil {
+		return err
+	}
+	// the aggregate vote only exists between the reveal and the end of the vote period
+	_, voteErr := m.oracleClient.AggregateVote(ctx, &oracletypes.QueryAggregateVoteRequest{ValidatorAddr: m.validator.String()})
+
+	missCounter, allowed := missResp.MissCounter, allowedMisses(paramsResp.Params)
+	validator := m.validator.String()
+	metrics.MissCounter.WithLabelValues(validator).Set(float64(missCounter))
+	metrics.AllowedMisses.WithLabelValues(validator).Set(float64(allowed))
+	hasVote := 0.0
+	if voteErr == nil {
+		hasVote = 1
+	}
+	metrics.AggregateVotePresent.WithLabelValues(validator).Set(hasVote)
+
+	if missCounter == m.lastMissCounter {
+		return nil
+	}
+	reset := missCounter < m.lastMissCounter
+	m.lastMissCounter = missCounter
+
+	logger := m.logger.With().Uint64("miss-counter", missCounter).Uint64("allowed-misses", allowed).Logger()
+	switch {
+	case reset:
+		logger.Info().Msg("miss counter reset by a new slash window")
+	case missCounter > allowed:
+		logger.Error().Msg("missed too many votes, the validator will be slashed at the end of the slash window")
+	case missCounter*10 >= allowed*8:
+		logger.Error().Msg("missed votes are close to the slashing threshold")
+	case missCounter*2 >= allowed:
+		logger.Warn().Msg("missed votes are halfway to the slashing threshold")
+	default:
+		logger.Info().Msg("missed a vote")
+	}
+	return nil
+}
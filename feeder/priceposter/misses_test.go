@@ -0,0 +1,83 @@
+package priceposter
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+
+	oracletypes "github.com/NibiruChain/nibiru/x/oracle/types"
+	"github.com/NibiruChain/pricefeeder/metrics"
+	"github.com/cosmos/cosmos-sdk/crypto/keys/secp256k1"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+)
+
+type mockOracle struct {
+	feeder        sdk.AccAddress
+	params        oracletypes.Params
+	missCounter   uint64
+	aggregateVote *oracletypes.AggregateExchangeRateVote
+	prevote       *oracletypes.AggregateExchangeRatePrevote
+}
+
+func (m *mockOracle) AggregatePrevote(context.Context, *oracletypes.QueryAggregatePrevoteRequest, ...grpc.CallOption) (*oracletypes.QueryAggregatePrevoteResponse, error) {
+	if m.prevote == nil {
+		return nil, errors.New("no aggregate prevote")
+	}
+	return &oracletypes.QueryAggregatePrevoteResponse{AggregatePrevote: *m.prevote}, nil
+}
+
+func (m *mockOracle) AggregateVote(context.Context, *oracletypes.QueryAggregateVoteRequest, ...grpc.CallOption) (*oracletypes.QueryAggregateVoteResponse, error) {
+	if m.aggregateVote == nil {
+		return nil, errors.New("no aggregate vote")
+	}
+	return &oracletypes.QueryAggregateVoteResponse{AggregateVote: *m.aggregateVote}, nil
+}
+
+func (m *mockOracle) FeederDelegation(context.Context, *oracletypes.QueryFeederDelegationRequest, ...grpc.CallOption) (*oracletypes.QueryFeederDelegationResponse, error) {
+	return &oracletypes.QueryFeederDelegationResponse{FeederAddr: m.feeder.String()}, nil
+}
+
+func (m *mockOracle) MissCounter(context.Context, *oracletypes.QueryMissCounterRequest, ...grpc.CallOption) (*oracletypes.QueryMissCounterResponse, error) {
+	return &oracletypes.QueryMissCounterResponse{MissCounter: m.missCounter}, nil
+}
+
+func (m *mockOracle) Params(context.Context, *oracletypes.QueryParamsRequest, ...grpc.CallOption) (*oracletypes.QueryParamsResponse, error) {
+	return &oracletypes.QueryParamsResponse{Params: m.params}, nil
+}
+
+func TestAllowedMisses(t *testing.T) {
+	params := oracletypes.Params{VotePeriod: 10, SlashWindow: 1000, MinValidPerWindow: sdk.MustNewDecFromStr("0.69")}
+	// 100 vote periods per window, at least 69 valid ones
+	require.Equal(t, uint64(31), allowedMisses(params))
+
+	params.MinValidPerWindow = sdk.OneDec()
+	require.Zero(t, allowedMisses(params))
+
+	params.VotePeriod = 0
+	require.Zero(t, allowedMisses(params))
+}
+
+func TestMissCounterMonitor(t *testing.T) {
+	validator := sdk.ValAddress(secp256k1.GenPrivKey().PubKey().Address())
+	oracle := &mockOracle{
+		params:      oracletypes.Params{VotePeriod: 10, SlashWindow: 1000, MinValidPerWindow: sdk.MustNewDecFromStr("0.69")},
+		missCounter: 20,
+	}
+	m := &missCounterMonitor{oracleClient: oracle, validator: validator, logger: zerolog.New(io.Discard)}
+
+	require.NoError(t, m.check(context.Background()))
+	require.Equal(t, 20.0, testutil.ToFloat64(metrics.MissCounter.WithLabelValues(validator.String())))
+	require.Equal(t, 31.0, testutil.ToFloat64(metrics.AllowedMisses.WithLabelValues(validator.String())))
+	require.Equal(t, 0.0, testutil.ToFloat64(metrics.AggregateVotePresent.WithLabelValues(validator.String())))
+
+	oracle.aggregateVote = &oracletypes.AggregateExchangeRateVote{Voter: validator.String()}
+	oracle.missCounter = 0
+	require.NoError(t, m.check(context.Background()))
+	require.Equal(t, 0.0, testutil.ToFloat64(metrics.MissCounter.WithLabelValues(validator.String())))
+	require.Equal(t, 1.0, testutil.ToFloat64(metrics.AggregateVotePresent.WithLabelValues(validator.String())))
+}
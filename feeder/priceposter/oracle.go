@@ -3,9 +3,11 @@ package priceposter
 import (
 	"context"
 	"crypto/rand"
+	"fmt"
+	"math/big"
 	"strconv"
 	"strings"
-	"math/big"
+	"time"
 
 	oracletypes "github.com/NibiruChain/nibiru/x/oracle/types"
 	"github.com/NibiruChain/pricefeeder/types"
@@ -13,13 +15,22 @@ import (
 	"github.com/rs/zerolog"
 )
 
-var (
-	// MaxSaltNumber is the maximum salt number we can use for randomness.
-	// NOTE: max length of the salt is 4.
-	// TODO(mercilex): if we used digits + alphanumerics it's more randomized
-	MaxSaltNumber = big.NewInt(9999) // NOTE(mercilex): max salt length is 4
+const (
+	// saltAlphabet are the characters salts are made of.
+	saltAlphabet = "0123456789abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ"
+	// saltLength is the maximum salt length accepted by the oracle module.
+	saltLength = 4
+)
+
+// Types of the txs sent by vote, as reported to metrics.TxBroadcastLatency.
+const (
+	txTypePrevote        = "prevote"
+	txTypeVoteAndPrevote = "vote_and_prevote"
 )
 
+// txTypeDelegateFeedConsent is the type of the tx sent by DelegateFeeder.
+const txTypeDelegateFeedConsent = "delegate_feed_consent"
+
 func vote(
 	ctx context.Context,
 	newPrevote, oldPrevote *prevote,
@@ -40,18 +51,31 @@ func vote(
 	}
 	// once we prepared the vote msg we can send the tx
 	var msgs = []sdk.Msg{newPrevote.msg}
+	txType := txTypePrevote
 	// if there was a vote then we of course need to vote first and then prevote.
 	if voteMsg != nil {
 		// note ordering matters because the new prevote will overwrite the old one
 		msgs = []sdk.Msg{voteMsg, newPrevote.msg}
+		txType = txTypeVoteAndPrevote
 	} else {
 		logger.Info().Msg("skipping vote preparation as there is no old prevote")
 	}
 
-	return sendTx(
-		ctx, deps.keyBase, deps.authClient, deps.txClient,
-		feeder, deps.txConfig, deps.ir, deps.chainID, msgs...,
-	)
+	sentAt := time.Now()
+	txResponse, err = sendTx(ctx, deps, feeder, logger, msgs...)
+	if err == nil {
+		logger.Info().Str("tx-hash", txResponse.TxHash).Msg("tx accepted by the mempool, waiting for inclusion")
+		var included *sdk.TxResponse
+		included, err = awaitInclusion(ctx, deps.txClient, validator, txResponse.TxHash, txType, sentAt, logger)
+		if included != nil {
+			txResponse = included
+		}
+	}
+
+	if voteMsg != nil {
+		reportVote(ctx, deps.oracleClient, validator, oldPrevote, err, logger)
+	}
+	return txResponse, err
 }
 
 func prepareVote(
@@ -79,18 +103,24 @@ func prepareVote(
 		return nil, nil
 	}
 
+	return newVoteMsg(prevote, validator, feeder), nil
+}
+
+// newVoteMsg returns the msg revealing the given prevote.
+func newVoteMsg(prevote *prevote, validator sdk.ValAddress, feeder sdk.AccAddress) *oracletypes.MsgAggregateExchangeRateVote {
 	return &oracletypes.MsgAggregateExchangeRateVote{
 		Salt:          prevote.salt,
 		ExchangeRates: prevote.vote,
 		Feeder:        feeder.String(),
 		Validator:     validator.String(),
-	}, nil
+	}
 }
 
 type prevote struct {
-	msg  *oracletypes.MsgAggregateExchangeRatePrevote
-	salt string
-	vote string
+	msg    *oracletypes.MsgAggregateExchangeRatePrevote
+	salt   string
+	vote   string
+	prices []types.Price // the prices encoded in vote, nil if unknown
 }
 
 func newPrevote(prices []types.Price, validator sdk.ValAddress, feeder sdk.AccAddress) *prevote {
@@ -98,7 +128,7 @@ func newPrevote(prices []types.Price, validator sdk.ValAddress, feeder sdk.AccAd
 	for i, price := range prices {
 		tuple[i] = oracletypes.ExchangeRateTuple{
 			Pair:         price.Pair,
-			ExchangeRate: float64ToDec(price.Price),
+			ExchangeRate: float64ToDec(price.ExchangeRate()),
 		}
 	}
 
@@ -106,19 +136,49 @@ func newPrevote(prices []types.Price, validator sdk.ValAddress, feeder sdk.AccAd
 	if err != nil {
 		panic(err)
 	}
-	nBig, err := rand.Int(rand.Reader, MaxSaltNumber)
-	if err != nil {
+	salt := newSalt()
+	hash := oracletypes.GetAggregateVoteHash(salt, votesStr, validator)
+
+	p := &prevote{
+		msg:    oracletypes.NewMsgAggregateExchangeRatePrevote(hash, feeder, validator),
+		salt:   salt,
+		vote:   votesStr,
+		prices: prices,
+	}
+	if err := verifyPrevote(p, validator); err != nil {
 		panic(err)
 	}
-	salt := nBig.String()
+	return p
+}
 
-	hash := oracletypes.GetAggregateVoteHash(salt, votesStr, validator)
+// newSalt returns a random salt of saltLength characters from saltAlphabet.
+func newSalt() string {
+	alphabetSize := big.NewInt(int64(len(saltAlphabet)))
+	salt := make([]byte, saltLength)
+	for i := range salt {
+		n, err := rand.Int(rand.Reader, alphabetSize)
+		if err != nil {
+			panic(err)
+		}
+		salt[i] = saltAlphabet[n.Int64()]
+	}
+	return string(salt)
+}
 
-	return &prevote{
-		msg:  oracletypes.NewMsgAggregateExchangeRatePrevote(hash, feeder, validator),
-		salt: salt,
-		vote: votesStr,
+// verifyPrevote checks that the hash of the prevote, once decoded as the oracle module
+// does, matches the hash of its salt and vote, so that the vote can be revealed.
+func verifyPrevote(p *prevote, validator sdk.ValAddress) error {
+	if len(p.salt) < 1 || len(p.salt) > saltLength {
+		return fmt.Errorf("invalid salt length %d", len(p.salt))
+	}
+	hash, err := oracletypes.AggregateVoteHashFromHexString(p.msg.Hash)
+	if err != nil {
+		return err
+	}
+	if !hash.Equal(oracletypes.GetAggregateVoteHash(p.salt, p.vote, validator)) {
+		return fmt.Errorf("prevote hash %s does not match its salt and vote", p.msg.Hash)
 	}
+	return nil
 }
 
 func float64ToDec(price float64) sdk.Dec {
@@ -0,0 +1,74 @@
+package priceposter
+
+import (
+	"strings"
+	"testing"
+	"testing/quick"
+
+	"github.com/NibiruChain/nibiru/x/common/asset"
+	"github.com/NibiruChain/nibiru/x/common/denoms"
+	oracletypes "github.com/NibiruChain/nibiru/x/oracle/types"
+	"github.com/NibiruChain/pricefeeder/types"
+	"github.com/cosmos/cosmos-sdk/crypto/keys/secp256k1"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewSalt(t *testing.T) {
+	property := func() bool {
+		salt := newSalt()
+		if len(salt) != saltLength {
+			return false
+		}
+		for _, c := range salt {
+			if !strings.ContainsRune(saltAlphabet, c) {
+				return false
+			}
+		}
+		return true
+	}
+	require.NoError(t, quick.Check(property, &quick.Config{MaxCount: 1000}))
+
+	// with 62^4 possible salts, collisions among a few hundred draws are very unlikely
+	seen := make(map[string]struct{})
+	for i := 0; i < 500; i++ {
+		seen[newSalt()] = struct{}{}
+	}
+	require.Greater(t, len(seen), 490)
+}
+
+func TestNewPrevote_HashRoundTrips(t *testing.T) {
+	feeder := sdk.AccAddress(secp256k1.GenPrivKey().PubKey().Address())
+
+	property := func(rate uint32, validatorBytes [20]byte) bool {
+		validator := sdk.ValAddress(validatorBytes[:])
+		prices := []types.Price{{Pair: asset.Registry.Pair(denoms.BTC, denoms.NUSD), Price: float64(rate) / 1000, SourceName: "test", Valid: true}}
+		p := newPrevote(prices, validator, feeder)
+
+		// the oracle module recomputes the hash from the revealed salt and vote
+		vote := oracletypes.NewMsgAggregateExchangeRateVote(p.salt, p.vote, feeder, validator)
+		hash, err := oracletypes.AggregateVoteHashFromHexString(p.msg.Hash)
+		return err == nil &&
+			vote.ValidateBasic() == nil &&
+			hash.Equal(oracletypes.GetAggregateVoteHash(vote.Salt, vote.ExchangeRates, validator)) &&
+			verifyPrevote(p, validator) == nil
+	}
+	require.NoError(t, quick.Check(property, nil))
+}
+
+func TestVerifyPrevote(t *testing.T) {
+	validator := sdk.ValAddress(secp256k1.GenPrivKey().PubKey().Address())
+	feeder := sdk.AccAddress(secp256k1.GenPrivKey().PubKey().Address())
+	prices := []types.Price{{Pair: asset.Registry.Pair(denoms.BTC, denoms.NUSD), Price: 100_000, SourceName: "test", Valid: true}}
+
+	p := newPrevote(prices, validator, feeder)
+	require.NoError(t, verifyPrevote(p, validator))
+
+	tampered := *p
+	tampered.salt = "zzzzz"
+	require.ErrorContains(t, verifyPrevote(&tampered, validator), "invalid salt length")
+
+	tampered = *p
+	tampered.vote = "1.0ubtc:uusd"
+	require.ErrorContains(t, verifyPrevote(&tampered, validator), "does not match")
+}
@@ -0,0 +1,95 @@
+package priceposter
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	oracletypes "github.com/NibiruChain/nibiru/x/oracle/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// errInvalidPrevoteState is returned when the stored prevote cannot be used.
+var errInvalidPrevoteState = errors.New("invalid prevote state")
+
+// prevoteStore persists the outstanding prevote to a local file, so that
+// its vote can still be revealed after the feeder restarts.
+type prevoteStore struct {
+	path string
+}
+
+// prevoteState is the on-disk representation of a prevote.
+type prevoteState struct {
+	Validator string `json:"validator"`
+	Height    uint64 `json:"height"`
+	Hash      string `json:"hash"`
+	Salt      string `json:"salt"`
+	Vote      string `json:"vote"`
+}
+
+// save atomically replaces the stored prevote: the state is written to a temporary
+// file in the same directory, synced to disk and then renamed over the old one.
+func (s prevoteStore) save(p *prevote, height uint64, validator sdk.ValAddress) error {
+	b, err := json.Marshal(prevoteState{
+		Validator: validator.String(),
+		Height:    height,
+		Hash:      p.msg.Hash,
+		Salt:      p.salt,
+		Vote:      p.vote,
+	})
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(s.path), filepath.Base(s.path)+".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name()) // no-op once renamed
+
+	if _, err := tmp.Write(b); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), s.path)
+}
+
+// load returns the stored prevote, or nil if there is none.
+// It fails if the stored prevote does not belong to the given validator
+// or if its hash does not match its salt and vote.
+func (s prevoteStore) load(validator sdk.ValAddress, feeder sdk.AccAddress) (*prevote, uint64, error) {
+	b, err := os.ReadFile(s.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, 0, nil
+	}
+	if err != nil {
+		return nil, 0, err
+	}
+
+	state := new(prevoteState)
+	if err := json.Unmarshal(b, state); err != nil {
+		return nil, 0, fmt.Errorf("%w: %s", errInvalidPrevoteState, err)
+	}
+	if state.Validator != validator.String() {
+		return nil, 0, fmt.Errorf("%w: prevote belongs to validator %s, not %s", errInvalidPrevoteState, state.Validator, validator)
+	}
+	hash := oracletypes.GetAggregateVoteHash(state.Salt, state.Vote, validator)
+	if hash.String() != state.Hash {
+		return nil, 0, fmt.Errorf("%w: hash %s does not match salt and vote", errInvalidPrevoteState, state.Hash)
+	}
+
+	return &prevote{
+		msg:  oracletypes.NewMsgAggregateExchangeRatePrevote(hash, feeder, validator),
+		salt: state.Salt,
+		vote: state.Vote,
+	}, state.Height, nil
+}
@@ -0,0 +1,93 @@
+package priceposter
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/NibiruChain/nibiru/x/common/asset"
+	"github.com/NibiruChain/nibiru/x/common/denoms"
+	"github.com/NibiruChain/pricefeeder/types"
+	"github.com/cosmos/cosmos-sdk/crypto/keys/secp256k1"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPrevoteStore(t *testing.T) {
+	feeder := sdk.AccAddress(secp256k1.GenPrivKey().PubKey().Address())
+	validator := sdk.ValAddress(feeder)
+	prices := []types.Price{{Pair: asset.Registry.Pair(denoms.BTC, denoms.NUSD), Price: 27_000.5, Valid: true}}
+
+	t.Run("missing file", func(t *testing.T) {
+		store := prevoteStore{path: filepath.Join(t.TempDir(), "prevote.json")}
+		p, height, err := store.load(validator, feeder)
+		require.NoError(t, err)
+		require.Nil(t, p)
+		require.Zero(t, height)
+	})
+
+	t.Run("save and load", func(t *testing.T) {
+		dir := t.TempDir()
+		store := prevoteStore{path: filepath.Join(dir, "prevote.json")}
+		saved := newPrevote(prices, validator, feeder)
+		require.NoError(t, store.save(saved, 100, validator))
+
+		// overwrite with a newer prevote
+		saved = newPrevote(prices, validator, feeder)
+		require.NoError(t, store.save(saved, 110, validator))
+
+		loaded, height, err := store.load(validator, feeder)
+		require.NoError(t, err)
+		require.Equal(t, uint64(110), height)
+		saved.prices = nil // the fetched prices are not persisted
+		require.Equal(t, saved, loaded)
+
+		// no temporary files are left behind
+		entries, err := os.ReadDir(dir)
+		require.NoError(t, err)
+		require.Len(t, entries, 1)
+	})
+
+	t.Run("different validator", func(t *testing.T) {
+		store := prevoteStore{path: filepath.Join(t.TempDir(), "prevote.json")}
+		require.NoError(t, store.save(newPrevote(prices, validator, feeder), 100, validator))
+
+		other := sdk.ValAddress(secp256k1.GenPrivKey().PubKey().Address())
+		_, _, err := store.load(other, feeder)
+		require.ErrorIs(t, err, errInvalidPrevoteState)
+	})
+
+	t.Run("tampered vote", func(t *testing.T) {
+		store := prevoteStore{path: filepath.Join(t.TempDir(), "prevote.json")}
+		p := newPrevote(prices, validator, feeder)
+		p.vote = "(ubtc:unusd,1.000000000000000000)"
+		require.NoError(t, store.save(p, 100, validator))
+
+		_, _, err := store.load(validator, feeder)
+		require.ErrorIs(t, err, errInvalidPrevoteState)
+	})
+
+	t.Run("client restores prevote", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "prevote.json")
+		saved := newPrevote(prices, validator, feeder)
+		require.NoError(t, prevoteStore{path: path}.save(saved, 100, validator))
+
+		c := &Client{logger: zerolog.New(io.Discard), validator: validator, feeder: feeder}
+		require.NoError(t, c.LoadPrevoteState(path))
+		saved.prices = nil // the fetched prices are not persisted
+		require.Equal(t, saved, c.previousPrevote)
+		require.NotNil(t, c.prevoteStore)
+	})
+
+	t.Run("client discards corrupted state", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "prevote.json")
+		require.NoError(t, os.WriteFile(path, []byte("{"), 0o600))
+
+		c := &Client{logger: zerolog.New(io.Discard), validator: validator, feeder: feeder}
+		require.NoError(t, c.LoadPrevoteState(path))
+		require.Nil(t, c.previousPrevote)
+		require.NotNil(t, c.prevoteStore)
+	})
+}
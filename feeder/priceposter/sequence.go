@@ -0,0 +1,92 @@
+package priceposter
+
+import (
+	"context"
+	"regexp"
+	"strconv"
+	"sync"
+
+	"github.com/NibiruChain/pricefeeder/metrics"
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// sequenceMismatchRegex matches the error returned by the ante handler
+// when a tx is signed with the wrong account sequence.
+var sequenceMismatchRegex = regexp.MustCompile(`account sequence mismatch, expected (\d+)`)
+
+// sequenceManager caches the account number and sequence of the feeder,
+// so that the account does not need to be queried before every tx.
+type sequenceManager struct {
+	mu         sync.Mutex
+	authClient Auth
+	ir         codectypes.InterfaceRegistry
+	address    sdk.AccAddress
+
+	loaded        bool
+	accountNumber uint64
+	sequence      uint64
+}
+
+func newSequenceManager(authClient Auth, ir codectypes.InterfaceRegistry, address sdk.AccAddress) *sequenceManager {
+	return &sequenceManager{
+		authClient: authClient,
+		ir:         ir,
+		address:    address,
+	}
+}
+
+// withSequence calls sendTx with the account number and sequence to sign the tx with.
+// Calls are serialized, so that concurrent txs never reuse the same sequence.
+// Once sendTx returns, the sequence is:
+//   - incremented locally if the tx was accepted,
+//   - resynced to the expected one if the tx failed with an account sequence mismatch,
+//   - otherwise queried again on the next call, since it's unknown whether it was consumed.
+func (m *sequenceManager) withSequence(
+	ctx context.Context,
+	sendTx func(accountNumber, sequence uint64) (*sdk.TxResponse, error),
+) (*sdk.TxResponse, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if !m.loaded {
+		accountNumber, sequence, err := getAccount(ctx, m.authClient, m.ir, m.address)
+		if err != nil {
+			return nil, err
+		}
+		m.accountNumber, m.sequence, m.loaded = accountNumber, sequence, true
+		m.reportSequence()
+	}
+
+	resp, err := sendTx(m.accountNumber, m.sequence)
+	switch expected, mismatch := parseSequenceMismatch(err); {
+	case err == nil:
+		m.sequence++
+	case mismatch:
+		m.sequence = expected
+	default:
+		m.loaded = false
+	}
+	m.reportSequence()
+	return resp, err
+}
+
+func (m *sequenceManager) reportSequence() {
+	metrics.AccountSequence.WithLabelValues(m.address.String()).Set(float64(m.sequence))
+}
+
+// parseSequenceMismatch returns the expected sequence if the error is an account sequence mismatch.
+func parseSequenceMismatch(err error) (uint64, bool) {
+	if err == nil {
+		return 0, false
+	}
+	match := sequenceMismatchRegex.FindStringSubmatch(err.Error())
+	if match == nil {
+		return 0, false
+	}
+	expected, parseErr := strconv.ParseUint(match[1], 10, 64)
+	if parseErr != nil {
+		return 0, false
+	}
+	return expected, true
+}
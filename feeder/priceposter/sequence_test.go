@@ -0,0 +1,79 @@
+package priceposter
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/NibiruChain/nibiru/app"
+	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
+	"github.com/cosmos/cosmos-sdk/crypto/keys/secp256k1"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+)
+
+type mockAuth struct {
+	account *authtypes.BaseAccount
+	queries int
+}
+
+func (m *mockAuth) Account(_ context.Context, _ *authtypes.QueryAccountRequest, _ ...grpc.CallOption) (*authtypes.QueryAccountResponse, error) {
+	m.queries++
+	anyAccount, err := codectypes.NewAnyWithValue(m.account)
+	if err != nil {
+		return nil, err
+	}
+	return &authtypes.QueryAccountResponse{Account: anyAccount}, nil
+}
+
+func TestSequenceManager(t *testing.T) {
+	address := sdk.AccAddress(secp256k1.GenPrivKey().PubKey().Address())
+	auth := &mockAuth{account: authtypes.NewBaseAccount(address, nil, 7, 10)}
+	m := newSequenceManager(auth, app.MakeEncodingConfig().InterfaceRegistry, address)
+	ctx := context.Background()
+
+	send := func(err error) (accountNumber, sequence uint64) {
+		_, _ = m.withSequence(ctx, func(a, s uint64) (*sdk.TxResponse, error) {
+			accountNumber, sequence = a, s
+			return &sdk.TxResponse{}, err
+		})
+		return
+	}
+
+	t.Run("loads the account once and increments locally", func(t *testing.T) {
+		accountNumber, sequence := send(nil)
+		require.Equal(t, uint64(7), accountNumber)
+		require.Equal(t, uint64(10), sequence)
+		_, sequence = send(nil)
+		require.Equal(t, uint64(11), sequence)
+		require.Equal(t, 1, auth.queries)
+	})
+
+	t.Run("resyncs on account sequence mismatch", func(t *testing.T) {
+		send(errors.New("tx failed: account sequence mismatch, expected 20, got 12: incorrect account sequence"))
+		_, sequence := send(nil)
+		require.Equal(t, uint64(20), sequence)
+		require.Equal(t, 1, auth.queries)
+	})
+
+	t.Run("queries the account again after other errors", func(t *testing.T) {
+		auth.account.Sequence = 30
+		send(errors.New("connection refused"))
+		_, sequence := send(nil)
+		require.Equal(t, uint64(30), sequence)
+		require.Equal(t, 2, auth.queries)
+	})
+}
+
+func TestParseSequenceMismatch(t *testing.T) {
+	expected, ok := parseSequenceMismatch(errors.New("rpc error: code = Unknown desc = account sequence mismatch, expected 5, got 4: incorrect account sequence"))
+	require.True(t, ok)
+	require.Equal(t, uint64(5), expected)
+
+	_, ok = parseSequenceMismatch(errors.New("insufficient fees"))
+	require.False(t, ok)
+	_, ok = parseSequenceMismatch(nil)
+	require.False(t, ok)
+}
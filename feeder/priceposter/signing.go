@@ -8,64 +8,85 @@ import (
 	"github.com/cosmos/cosmos-sdk/client"
 	"github.com/cosmos/cosmos-sdk/client/tx"
 	codectypes "github.com/cosmos/cosmos-sdk/codec/types"
-	"github.com/cosmos/cosmos-sdk/crypto/keyring"
+	cryptotypes "github.com/cosmos/cosmos-sdk/crypto/types"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	txservice "github.com/cosmos/cosmos-sdk/types/tx"
 	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	"github.com/rs/zerolog"
 )
 
 func sendTx(
 	ctx context.Context,
-	keyBase keyring.Keyring,
-	authClient Auth,
-	txClient TxService,
+	deps deps,
 	feeder sdk.AccAddress,
-	txConfig client.TxConfig,
-	ir codectypes.InterfaceRegistry,
-	chainID string,
+	logger zerolog.Logger,
 	msgs ...sdk.Msg,
 ) (*sdk.TxResponse, error) {
+	txBuilder, keyName, pubKey := newTxBuilder(deps, feeder, msgs...)
+
+	// retry while the mempool rejects the tx for a reason a new attempt can fix
+	for attempt := 0; ; attempt++ {
+		resp, err := deps.sequences.withSequence(ctx, func(accNum, sequence uint64) (*sdk.TxResponse, error) {
+			return signAndBroadcast(ctx, deps, txBuilder, keyName, pubKey, accNum, sequence, attempt, logger)
+		})
+		if err == nil && deps.lastFees != nil {
+			deps.lastFees.set(txBuilder.GetTx().GetFee())
+		}
+		if err == nil || attempt+1 >= MaxBroadcastAttempts || ctx.Err() != nil || !isRetryable(resp, err) {
+			return resp, err
+		}
+		logger.Warn().Err(err).Int("attempt", attempt+1).Msg("tx rejected, retrying")
+	}
+}
+
+// newTxBuilder returns a builder for a tx with the given msgs,
+// along with the name and public key of the feeder's key.
+func newTxBuilder(deps deps, feeder sdk.AccAddress, msgs ...sdk.Msg) (client.TxBuilder, string, cryptotypes.PubKey) {
 	// get key from keybase, can't fail
-	keyInfo, err := keyBase.KeyByAddress(feeder)
+	keyInfo, err := deps.keyBase.KeyByAddress(feeder)
+	if err != nil {
+		panic(err)
+	}
+	pubKey, err := keyInfo.GetPubKey()
 	if err != nil {
 		panic(err)
 	}
 
 	// set msgs, can't fail
-	txBuilder := txConfig.NewTxBuilder()
+	txBuilder := deps.txConfig.NewTxBuilder()
 	err = txBuilder.SetMsgs(msgs...)
 	if err != nil {
 		panic(err)
 	}
-
-	txBuilder.SetFeeAmount(sdk.NewCoins(sdk.NewInt64Coin("unibi", 125)))
-	txBuilder.SetGasLimit(5_000)
-
-	// get acc info, can fail
-	accNum, sequence, err := getAccount(ctx, authClient, ir, feeder)
-	if err != nil {
-		return nil, err
+	if !deps.fees.Granter.Empty() {
+		txBuilder.SetFeeGranter(deps.fees.Granter)
 	}
 
-	txFactory := tx.Factory{}.
-		WithChainID(chainID).
-		WithKeybase(keyBase).
-		WithTxConfig(txConfig).
-		WithAccountNumber(accNum).
-		WithSequence(sequence)
+	return txBuilder, keyInfo.Name, pubKey
+}
 
-	// sign tx, can't fail
-	err = tx.Sign(txFactory, keyInfo.Name, txBuilder, true)
-	if err != nil {
-		panic(err)
+// signAndBroadcast estimates the gas and fees of the tx being built,
+// signs it with the given account number and sequence and broadcasts it.
+func signAndBroadcast(
+	ctx context.Context,
+	deps deps,
+	txBuilder client.TxBuilder,
+	keyName string,
+	pubKey cryptotypes.PubKey,
+	accNum, sequence uint64,
+	attempt int,
+	logger zerolog.Logger,
+) (*sdk.TxResponse, error) {
+	if err := setFees(ctx, deps, txBuilder, pubKey, sequence, attempt, logger); err != nil {
+		return nil, err
 	}
 
-	txBytes, err := txConfig.TxEncoder()(txBuilder.GetTx())
+	txBytes, err := signTx(deps, txBuilder, keyName, accNum, sequence)
 	if err != nil {
-		panic(err)
+		return nil, err
 	}
 
-	resp, err := txClient.BroadcastTx(ctx, &txservice.BroadcastTxRequest{
+	resp, err := deps.txClient.BroadcastTx(ctx, &txservice.BroadcastTxRequest{
 		TxBytes: txBytes,
 		Mode:    txservice.BroadcastMode_BROADCAST_MODE_SYNC,
 	})
@@ -78,6 +99,53 @@ func sendTx(
 	return resp.TxResponse, nil
 }
 
+// setFees estimates the gas of the tx being built and sets its gas limit and fees.
+func setFees(
+	ctx context.Context,
+	deps deps,
+	txBuilder client.TxBuilder,
+	pubKey cryptotypes.PubKey,
+	sequence uint64,
+	attempt int,
+	logger zerolog.Logger,
+) error {
+	gasLimit, err := simulateGas(ctx, deps.txClient, deps.txConfig, txBuilder, pubKey, sequence, deps.fees.GasAdjustment)
+	if err != nil {
+		return err
+	}
+	fees := computeFees(bumpGasPrices(gasPrices(ctx, deps.nodeClient, deps.fees, logger), attempt), gasLimit)
+	if err := checkFeeBudget(fees, deps.fees.MaxFee); err != nil {
+		return err
+	}
+	logger.Debug().Uint64("gas-limit", gasLimit).Str("fees", fees.String()).Msg("estimated tx fees")
+
+	txBuilder.SetGasLimit(gasLimit)
+	txBuilder.SetFeeAmount(fees)
+	return nil
+}
+
+// signTx signs the tx being built with the given account number and sequence and encodes it.
+func signTx(deps deps, txBuilder client.TxBuilder, keyName string, accNum, sequence uint64) ([]byte, error) {
+	txFactory := tx.Factory{}.
+		WithChainID(deps.chainID).
+		WithKeybase(deps.keyBase).
+		WithTxConfig(deps.txConfig).
+		WithAccountNumber(accNum).
+		WithSequence(sequence)
+
+	// local keyrings can't fail to sign, remote signers can
+	err := tx.Sign(txFactory, keyName, txBuilder, true)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign tx: %w", err)
+	}
+
+	txBytes, err := deps.txConfig.TxEncoder()(txBuilder.GetTx())
+	if err != nil {
+		panic(err)
+	}
+	return txBytes, nil
+}
+
 func getAccount(ctx context.Context, authClient Auth, ir codectypes.InterfaceRegistry, feeder sdk.AccAddress) (uint64, uint64, error) {
 	accRaw, err := authClient.Account(ctx, &authtypes.QueryAccountRequest{Address: feeder.String()})
 	if err != nil {
@@ -0,0 +1,92 @@
+package priceposter
+
+import (
+	"context"
+	"errors"
+	"math"
+
+	oracletypes "github.com/NibiruChain/nibiru/x/oracle/types"
+	"github.com/NibiruChain/pricefeeder/metrics"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/rs/zerolog"
+)
+
+// priceTolerance is the relative difference between a fetched price and its on-chain
+// exchange rate above which the price is considered mangled by its encoding.
+const priceTolerance = 1e-9
+
+// verifyVote compares the validator's aggregate vote on chain with the exchange rates
+// revealed from the given prevote, and reports for every pair whether the chain accepted it.
+func verifyVote(ctx context.Context, oracleClient Oracle, validator sdk.ValAddress, revealed *prevote, logger zerolog.Logger) error {
+	submitted, err := oracletypes.ParseExchangeRateTuples(revealed.vote)
+	if err != nil {
+		return err
+	}
+
+	resp, err := oracleClient.AggregateVote(ctx, &oracletypes.QueryAggregateVoteRequest{ValidatorAddr: validator.String()})
+	if err != nil {
+		reportVoteRejected(validator, revealed)
+		return err
+	}
+
+	onChain := make(map[string]sdk.Dec, len(resp.AggregateVote.ExchangeRateTuples))
+	for _, tuple := range resp.AggregateVote.ExchangeRateTuples {
+		onChain[tuple.Pair.String()] = tuple.ExchangeRate
+	}
+
+	for _, tuple := range submitted {
+		pair := tuple.Pair.String()
+		rate, found := onChain[pair]
+		switch {
+		case !found:
+			logger.Warn().Str("pair", pair).Msg("pair missing from the on-chain aggregate vote")
+		case !rate.Equal(tuple.ExchangeRate):
+			logger.Warn().Str("pair", pair).Str("submitted", tuple.ExchangeRate.String()).Str("on-chain", rate.String()).Msg("on-chain exchange rate differs from the submitted one")
+		}
+		accepted := 0.0
+		if found && rate.Equal(tuple.ExchangeRate) {
+			accepted = 1
+		}
+		metrics.VoteAccepted.WithLabelValues(validator.String(), pair).Set(accepted)
+	}
+
+	// the fetched prices are unknown if the prevote was restored from disk
+	for _, price := range revealed.prices {
+		rate, found := onChain[price.Pair.String()]
+		if !found || !price.Valid {
+			continue
+		}
+		if onChainPrice := rate.MustFloat64(); math.Abs(onChainPrice-price.Price) > priceTolerance*math.Abs(price.Price) {
+			logger.Warn().Str("pair", price.Pair.String()).Float64("price", price.Price).Str("on-chain", rate.String()).Msg("price altered by its on-chain encoding")
+		}
+	}
+	return nil
+}
+
+// reportVote reports whether the chain accepted the exchange rates revealed from the given prevote,
+// given the error of the tx revealing them. A tx still pending once the voting period ends may be
+// included later, so the pairs of its vote are left as they were.
+func reportVote(ctx context.Context, oracleClient Oracle, validator sdk.ValAddress, revealed *prevote, txErr error, logger zerolog.Logger) {
+	switch {
+	case errors.Is(txErr, errTxExpired):
+		logger.Warn().Msg("vote not confirmed before the end of the voting period, its acceptance is unknown")
+	case txErr != nil:
+		reportVoteRejected(validator, revealed)
+	default:
+		// check that the chain recorded the revealed exchange rates
+		if err := verifyVote(ctx, oracleClient, validator, revealed, logger); err != nil {
+			logger.Err(err).Msg("failed to verify the aggregate vote")
+		}
+	}
+}
+
+// reportVoteRejected reports every pair of a vote which did not reach the chain as not accepted.
+func reportVoteRejected(validator sdk.ValAddress, revealed *prevote) {
+	submitted, err := oracletypes.ParseExchangeRateTuples(revealed.vote)
+	if err != nil {
+		return
+	}
+	for _, tuple := range submitted {
+		metrics.VoteAccepted.WithLabelValues(validator.String(), tuple.Pair.String()).Set(0)
+	}
+}
@@ -0,0 +1,83 @@
+package priceposter
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"testing"
+
+	"github.com/NibiruChain/nibiru/x/common/asset"
+	"github.com/NibiruChain/nibiru/x/common/denoms"
+	oracletypes "github.com/NibiruChain/nibiru/x/oracle/types"
+	"github.com/NibiruChain/pricefeeder/metrics"
+	"github.com/NibiruChain/pricefeeder/types"
+	"github.com/cosmos/cosmos-sdk/crypto/keys/secp256k1"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerifyVote(t *testing.T) {
+	feeder := sdk.AccAddress(secp256k1.GenPrivKey().PubKey().Address())
+	validator := sdk.ValAddress(feeder)
+	btc, eth := asset.Registry.Pair(denoms.BTC, denoms.NUSD), asset.Registry.Pair(denoms.ETH, denoms.NUSD)
+	revealed := newPrevote([]types.Price{
+		{Pair: btc, Price: 27_000.5, Valid: true},
+		{Pair: eth, Price: 1_600, Valid: true},
+	}, validator, feeder)
+	accepted := func(pair asset.Pair) float64 {
+		return testutil.ToFloat64(metrics.VoteAccepted.WithLabelValues(validator.String(), pair.String()))
+	}
+
+	t.Run("all pairs accepted", func(t *testing.T) {
+		oracle := &mockOracle{aggregateVote: &oracletypes.AggregateExchangeRateVote{
+			ExchangeRateTuples: oracletypes.ExchangeRateTuples{
+				{Pair: btc, ExchangeRate: sdk.MustNewDecFromStr("27000.5")},
+				{Pair: eth, ExchangeRate: sdk.MustNewDecFromStr("1600")},
+			},
+		}}
+		require.NoError(t, verifyVote(context.Background(), oracle, validator, revealed, zerolog.New(io.Discard)))
+		require.Equal(t, 1.0, accepted(btc))
+		require.Equal(t, 1.0, accepted(eth))
+	})
+
+	t.Run("missing and different pairs", func(t *testing.T) {
+		oracle := &mockOracle{aggregateVote: &oracletypes.AggregateExchangeRateVote{
+			ExchangeRateTuples: oracletypes.ExchangeRateTuples{
+				{Pair: btc, ExchangeRate: sdk.MustNewDecFromStr("27000")},
+			},
+		}}
+		require.NoError(t, verifyVote(context.Background(), oracle, validator, revealed, zerolog.New(io.Discard)))
+		require.Equal(t, 0.0, accepted(btc))
+		require.Equal(t, 0.0, accepted(eth))
+	})
+
+	t.Run("no aggregate vote", func(t *testing.T) {
+		require.Error(t, verifyVote(context.Background(), &mockOracle{}, validator, revealed, zerolog.New(io.Discard)))
+		require.Equal(t, 0.0, accepted(btc))
+	})
+}
+
+func TestReportVote(t *testing.T) {
+	feeder := sdk.AccAddress(secp256k1.GenPrivKey().PubKey().Address())
+	validator := sdk.ValAddress(feeder)
+	btc := asset.Registry.Pair(denoms.BTC, denoms.NUSD)
+	revealed := newPrevote([]types.Price{{Pair: btc, Price: 27_000.5, Valid: true}}, validator, feeder)
+	accepted := func() float64 {
+		return testutil.ToFloat64(metrics.VoteAccepted.WithLabelValues(validator.String(), btc.String()))
+	}
+	oracle := &mockOracle{aggregateVote: &oracletypes.AggregateExchangeRateVote{
+		ExchangeRateTuples: oracletypes.ExchangeRateTuples{{Pair: btc, ExchangeRate: sdk.MustNewDecFromStr("27000.5")}},
+	}}
+
+	reportVote(context.Background(), oracle, validator, revealed, nil, zerolog.New(io.Discard))
+	require.Equal(t, 1.0, accepted())
+
+	// a tx still pending might be included later
+	reportVote(context.Background(), oracle, validator, revealed, errTxExpired, zerolog.New(io.Discard))
+	require.Equal(t, 1.0, accepted())
+
+	reportVote(context.Background(), oracle, validator, revealed, fmt.Errorf("tx failed in block 10"), zerolog.New(io.Discard))
+	require.Equal(t, 0.0, accepted())
+}
@@ -1,13 +1,16 @@
 package priceprovider
 
 import (
+	"bytes"
+	"context"
 	"encoding/json"
+	"reflect"
+	"sync"
 
 	"github.com/NibiruChain/nibiru/x/common/asset"
+	"github.com/NibiruChain/pricefeeder/feeder/priceprovider/sources"
 	"github.com/NibiruChain/pricefeeder/metrics"
 	"github.com/NibiruChain/pricefeeder/types"
-	"github.com/prometheus/client_golang/prometheus"
-	"github.com/prometheus/client_golang/prometheus/promauto"
 	"github.com/rs/zerolog"
 )
 
@@ -17,8 +20,17 @@ var _ types.PriceProvider = (*AggregatePriceProvider)(nil)
 // It gets prices from multiple exchanges and returns the first valid price
 // it finds for each trading pair.
 type AggregatePriceProvider struct {
-	logger    zerolog.Logger
-	providers map[int]types.PriceProvider // we use a map here to provide random ranging (since golang's map range is unordered)
+	logger         zerolog.Logger
+	providerLogger zerolog.Logger // logger of the price providers, without the aggregate component
+	mu             sync.RWMutex
+	providers      map[string]*sourceProvider // we use a map here to provide random ranging (since golang's map range is unordered)
+}
+
+// sourceProvider is the price provider of a source along with the configuration it was created with.
+type sourceProvider struct {
+	types.PriceProvider
+	pairToSymbolMap map[asset.Pair]types.Symbol
+	config          json.RawMessage
 }
 
 // NewAggregatePriceProvider creates an AggregatePriceProvider that manages
@@ -28,54 +40,120 @@ func NewAggregatePriceProvider(
 	sourcesToPairSymbolMap map[string]map[asset.Pair]types.Symbol,
 	sourceConfigMap map[string]json.RawMessage,
 	logger zerolog.Logger,
-) types.PriceProvider {
-	providers := make(map[int]types.PriceProvider, len(sourcesToPairSymbolMap))
-	i := 0
+) *AggregatePriceProvider {
+	a := &AggregatePriceProvider{
+		logger:         logger.With().Str("component", "aggregate-price-provider").Logger(),
+		providerLogger: logger,
+		providers:      make(map[string]*sourceProvider, len(sourcesToPairSymbolMap)),
+	}
 	for sourceName, pairToSymbolMap := range sourcesToPairSymbolMap {
-		providers[i] = NewPriceProvider(sourceName, pairToSymbolMap, sourceConfigMap[sourceName], logger)
-		i++
+		a.providers[sourceName] = a.newSourceProvider(sourceName, pairToSymbolMap, sourceConfigMap[sourceName])
 	}
+	return a
+}
+
+func (a *AggregatePriceProvider) newSourceProvider(sourceName string, pairToSymbolMap map[asset.Pair]types.Symbol, config json.RawMessage) *sourceProvider {
+	return &sourceProvider{
+		PriceProvider:   NewPriceProvider(sourceName, pairToSymbolMap, config, a.providerLogger),
+		pairToSymbolMap: pairToSymbolMap,
+		config:          config,
+	}
+}
 
-	return AggregatePriceProvider{
-		logger:    logger.With().Str("component", "aggregate-price-provider").Logger(),
-		providers: providers,
+// copyLastPrices copies the last prices of the provider it replaces, see PriceProvider.copyLastPrices.
+func (p *sourceProvider) copyLastPrices(replaced *sourceProvider) {
+	to, ok := p.PriceProvider.(*PriceProvider)
+	if !ok {
+		return
+	}
+	if from, ok := replaced.PriceProvider.(*PriceProvider); ok {
+		to.copyLastPrices(from)
 	}
 }
 
-// Prometheus metric for tracking price aggregation success/failure
-var aggregatePriceProvider = promauto.NewCounterVec(prometheus.CounterOpts{
-	Namespace: metrics.PrometheusNamespace,
-	Name:      "aggregate_prices_total",
-	Help:      "The total number prices provided by the aggregate price provider, by pair, source, and success status",
-}, []string{"pair", "source", "success"})
+// Reload applies a new configuration of the sources. Only the price providers of the sources whose
+// symbols or config changed are replaced, the others keep running along with their last prices.
+// A replacing provider starts with the last prices of the symbols it shares with the replaced one,
+// so that they are not abstained from until its first fetch.
+// All the sources are validated before anything is replaced, so on error the current providers are kept.
+func (a *AggregatePriceProvider) Reload(
+	sourcesToPairSymbolMap map[string]map[asset.Pair]types.Symbol,
+	sourceConfigMap map[string]json.RawMessage,
+) error {
+	for sourceName := range sourcesToPairSymbolMap {
+		if err := sources.ValidateConfig(sourceName, sourceConfigMap[sourceName]); err != nil {
+			return err
+		}
+	}
+
+	a.mu.Lock()
+	providers := make(map[string]*sourceProvider, len(sourcesToPairSymbolMap))
+	for sourceName, pairToSymbolMap := range sourcesToPairSymbolMap {
+		config := sourceConfigMap[sourceName]
+		current, found := a.providers[sourceName]
+		if found && reflect.DeepEqual(current.pairToSymbolMap, pairToSymbolMap) && bytes.Equal(current.config, config) {
+			providers[sourceName] = current
+			continue
+		}
+		a.logger.Info().Str("source", sourceName).Msg("starting price provider with the new configuration")
+		provider := a.newSourceProvider(sourceName, pairToSymbolMap, config)
+		if found {
+			provider.copyLastPrices(current)
+		}
+		providers[sourceName] = provider
+	}
+	var replaced []*sourceProvider
+	for sourceName, current := range a.providers {
+		if providers[sourceName] != current {
+			replaced = append(replaced, current)
+		}
+	}
+	a.providers = providers
+	a.mu.Unlock()
+
+	// closed once no longer used by GetPrice
+	for _, p := range replaced {
+		p.Close()
+	}
+	return nil
+}
 
 // GetPrice fetches the first available and correct price from the wrapped PriceProviders.
 // It iterates through the available providers in a randomized order until it finds
-// a valid price. If no valid price is found, it returns an invalid price.
-func (a AggregatePriceProvider) GetPrice(pair asset.Pair) types.Price {
+// a valid price. If no valid price is found, or the context is done first, it returns an invalid price.
+// The pair is reported as not configured only if none of the providers has a symbol for it.
+func (a *AggregatePriceProvider) GetPrice(ctx context.Context, pair asset.Pair) types.Price {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	reason := types.AbstainNotConfigured
 	// iterate randomly, if we find a valid price, we return it
 	// otherwise we go onto the next PriceProvider to ask for prices.
 	for _, p := range a.providers {
-		price := p.GetPrice(pair)
+		if ctx.Err() != nil {
+			reason = types.AbstainTimeout
+			break
+		}
+		price := p.GetPrice(ctx, pair)
 		if price.Valid {
-			aggregatePriceProvider.WithLabelValues(pair.String(), price.SourceName, "true").Inc()
+			metrics.AggregatePriceCounter.WithLabelValues(pair.String(), price.SourceName, "true").Inc()
 			return price
 		}
+		if price.AbstainReason != types.AbstainNotConfigured {
+			reason = price.AbstainReason
+		}
 	}
 
 	// if we reach here no valid symbols were found
-	a.logger.Warn().Str("pair", pair.String()).Msg("no valid price found")
-	aggregatePriceProvider.WithLabelValues(pair.String(), "missing", "false").Inc()
-	return types.Price{
-		SourceName: "missing",
-		Pair:       pair,
-		Price:      0,
-		Valid:      false,
-	}
+	a.logger.Warn().Str("pair", pair.String()).Str("reason", string(reason)).Msg("no valid price found")
+	metrics.AggregatePriceCounter.WithLabelValues(pair.String(), "missing", "false").Inc()
+	return types.NewAbstainPrice(pair, "missing", reason)
 }
 
 // Close properly shuts down all underlying price providers.
-func (a AggregatePriceProvider) Close() {
+func (a *AggregatePriceProvider) Close() {
+	a.mu.Lock()
+	defer a.mu.Unlock()
 	for _, p := range a.providers {
 		p.Close()
 	}
@@ -0,0 +1,80 @@
+package priceprovider
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/NibiruChain/nibiru/x/common/asset"
+	"github.com/NibiruChain/nibiru/x/common/denoms"
+	"github.com/NibiruChain/pricefeeder/feeder/priceprovider/sources"
+	"github.com/NibiruChain/pricefeeder/types"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAggregatePriceProvider_Reload(t *testing.T) {
+	btc := asset.Registry.Pair(denoms.BTC, denoms.NUSD)
+	bitfinexSymbols := map[asset.Pair]types.Symbol{btc: "tBTCUSD"}
+	a := NewAggregatePriceProvider(map[string]map[asset.Pair]types.Symbol{sources.Bitfinex: bitfinexSymbols}, nil, zerolog.New(io.Discard))
+	defer a.Close()
+	bitfinex := a.providers[sources.Bitfinex]
+
+	t.Run("keeps the current providers on an invalid config", func(t *testing.T) {
+		err := a.Reload(map[string]map[asset.Pair]types.Symbol{
+			sources.Bitfinex: bitfinexSymbols,
+			"unknown":        {btc: "BTC"},
+		}, nil)
+		require.ErrorContains(t, err, "unknown price source")
+
+		err = a.Reload(map[string]map[asset.Pair]types.Symbol{
+			sources.Coingecko: {btc: "bitcoin"},
+		}, map[string]json.RawMessage{sources.Coingecko: json.RawMessage(`{"api_key": 1}`)})
+		require.ErrorContains(t, err, "invalid coingecko config")
+
+		require.Len(t, a.providers, 1)
+		require.Same(t, bitfinex, a.providers[sources.Bitfinex])
+	})
+
+	t.Run("replaces only the changed providers", func(t *testing.T) {
+		require.NoError(t, a.Reload(map[string]map[asset.Pair]types.Symbol{
+			sources.Bitfinex: {btc: "tBTCUSD"},
+			sources.Binance:  {btc: "BTCUSD"},
+		}, nil))
+		require.Len(t, a.providers, 2)
+		require.Same(t, bitfinex, a.providers[sources.Bitfinex])
+		binance := a.providers[sources.Binance]
+
+		require.NoError(t, a.Reload(map[string]map[asset.Pair]types.Symbol{
+			sources.Binance: {btc: "BTCUSDT"},
+		}, nil))
+		require.Len(t, a.providers, 1)
+		require.NotSame(t, binance, a.providers[sources.Binance])
+		require.Equal(t, types.Symbol("BTCUSDT"), a.providers[sources.Binance].pairToSymbolMap[btc])
+	})
+
+	t.Run("keeps the prices of the unchanged symbols", func(t *testing.T) {
+		eth := asset.Registry.Pair(denoms.ETH, denoms.NUSD)
+		require.NoError(t, a.Reload(map[string]map[asset.Pair]types.Symbol{
+			sources.Bitfinex: {btc: "tBTCUSD", eth: "tETHUSD"},
+		}, nil))
+		// as if fetched, the first fetch only happens after UpdateTick
+		provider := a.providers[sources.Bitfinex].PriceProvider.(*PriceProvider)
+		provider.lastPricesMutex.Lock()
+		provider.lastPrices["tBTCUSD"] = types.RawPrice{Price: 100_000, UpdateTime: time.Now()}
+		provider.lastPrices["tETHUSD"] = types.RawPrice{Price: 7_000, UpdateTime: time.Now()}
+		provider.lastPricesMutex.Unlock()
+		require.True(t, a.GetPrice(context.Background(), btc).Valid)
+
+		require.NoError(t, a.Reload(map[string]map[asset.Pair]types.Symbol{
+			sources.Bitfinex: {btc: "tBTCUSD", eth: "tETHUSDT"},
+		}, nil))
+		require.NotSame(t, provider, a.providers[sources.Bitfinex].PriceProvider)
+		price := a.GetPrice(context.Background(), btc)
+		require.True(t, price.Valid)
+		require.Equal(t, 100_000.0, price.Price)
+		require.Equal(t, types.AbstainStale, a.GetPrice(context.Background(), eth).AbstainReason)
+	})
+}
@@ -1,6 +1,7 @@
 package priceprovider
 
 import (
+	"context"
 	"encoding/json"
 	"sync"
 	"time"
@@ -100,30 +101,48 @@ func (p *PriceProvider) loop() {
 // GetPrice returns the types.Price for the given asset.Pair
 // in case price has expired, or for some reason it's impossible to
 // get the last available price, then an invalid types.Price is returned.
-func (p *PriceProvider) GetPrice(pair asset.Pair) types.Price {
+// The price is served from the last fetched prices, so GetPrice never blocks on the context.
+func (p *PriceProvider) GetPrice(ctx context.Context, pair asset.Pair) types.Price {
 	symbol, symbolExists := p.pairToSymbolMapping[pair]
 	// in case this is an unknown symbol, which might happen
 	// when for example we have a param update, then we return
 	// an abstain vote on the provided asset pair.
 	if !symbolExists {
 		p.logger.Debug().Str("pair", pair.String()).Msg("pair not configured for this pricefeeder")
-		return types.Price{
-			Pair:       pair,
-			Price:      -1, // abstain
-			SourceName: p.sourceName,
-			Valid:      false,
-		}
+		return types.NewAbstainPrice(pair, p.sourceName, types.AbstainNotConfigured)
 	}
 
 	p.lastPricesMutex.Lock()
 	price, priceExists := p.lastPrices[symbol]
 	p.lastPricesMutex.Unlock()
 
+	if !isValid(price, priceExists) {
+		return types.NewAbstainPrice(pair, p.sourceName, types.AbstainStale)
+	}
 	return types.Price{
 		Pair:       pair,
 		Price:      price.Price,
 		SourceName: p.sourceName,
-		Valid:      isValid(price, priceExists),
+		Valid:      true,
+	}
+}
+
+// copyLastPrices copies the last prices of the symbols p fetches from another provider of the same source,
+// so that p serves them until its first fetch. Prices p already fetched are kept if more recent.
+func (p *PriceProvider) copyLastPrices(from *PriceProvider) {
+	from.lastPricesMutex.Lock()
+	defer from.lastPricesMutex.Unlock()
+	p.lastPricesMutex.Lock()
+	defer p.lastPricesMutex.Unlock()
+
+	for _, symbol := range p.pairToSymbolMapping {
+		price, found := from.lastPrices[symbol]
+		if !found {
+			continue
+		}
+		if current, ok := p.lastPrices[symbol]; !ok || current.UpdateTime.Before(price.UpdateTime) {
+			p.lastPrices[symbol] = price
+		}
 	}
 }
 
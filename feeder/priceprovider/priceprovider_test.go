@@ -1,6 +1,7 @@
 package priceprovider
 
 import (
+	"context"
 	"encoding/json"
 	"io"
 	"testing"
@@ -37,7 +38,7 @@ func TestPriceProvider(t *testing.T) {
 		defer pp.Close()
 		<-time.After(sources.UpdateTick + 2*time.Second)
 
-		price := pp.GetPrice(asset.Registry.Pair(denoms.BTC, denoms.NUSD))
+		price := pp.GetPrice(context.Background(), asset.Registry.Pair(denoms.BTC, denoms.NUSD))
 		require.True(t, price.Valid)
 		require.Equal(t, asset.Registry.Pair(denoms.BTC, denoms.NUSD), price.Pair)
 		require.Equal(t, sources.Bitfinex, price.SourceName)
@@ -56,9 +57,10 @@ func TestPriceProvider(t *testing.T) {
 
 	t.Run("returns invalid price on unknown AssetPair", func(t *testing.T) {
 		pp := newPriceProvider(testAsyncSource{}, "test", map[asset.Pair]types.Symbol{}, zerolog.New(io.Discard))
-		price := pp.GetPrice(asset.Registry.Pair(denoms.BTC, denoms.NUSD))
+		price := pp.GetPrice(context.Background(), asset.Registry.Pair(denoms.BTC, denoms.NUSD))
 		require.False(t, price.Valid)
-		require.Equal(t, float64(-1), price.Price)
+		require.Equal(t, types.AbstainExchangeRate, price.Price)
+		require.Equal(t, types.AbstainNotConfigured, price.AbstainReason)
 		require.Equal(t, asset.Registry.Pair(denoms.BTC, denoms.NUSD), price.Pair)
 	})
 
@@ -71,7 +73,7 @@ func TestPriceProvider(t *testing.T) {
 		pp := newPriceProvider(source, "test", map[asset.Pair]types.Symbol{asset.Registry.Pair(denoms.BTC, denoms.NUSD): "BTC:NUSD"}, zerolog.New(io.Discard))
 
 		priceUpdatesC <- map[types.Symbol]types.RawPrice{"BTC:NUSD": {Price: 10, UpdateTime: time.Now()}}
-		price := pp.GetPrice(asset.Registry.Pair(denoms.BTC, denoms.NUSD))
+		price := pp.GetPrice(context.Background(), asset.Registry.Pair(denoms.BTC, denoms.NUSD))
 
 		require.True(t, price.Valid)
 		require.Equal(t, float64(10), price.Price)
@@ -79,6 +81,21 @@ func TestPriceProvider(t *testing.T) {
 		require.Equal(t, "test", price.SourceName)
 	})
 
+	t.Run("returns stale price as an abstain", func(t *testing.T) {
+		priceUpdatesC := make(chan map[types.Symbol]types.RawPrice)
+		source := testAsyncSource{
+			priceUpdatesC: priceUpdatesC,
+			closeFn:       func() { close(priceUpdatesC) },
+		}
+		pp := newPriceProvider(source, "test", map[asset.Pair]types.Symbol{asset.Registry.Pair(denoms.BTC, denoms.NUSD): "BTC:NUSD"}, zerolog.New(io.Discard))
+
+		priceUpdatesC <- map[types.Symbol]types.RawPrice{"BTC:NUSD": {Price: 10, UpdateTime: time.Now().Add(-2 * types.PriceTimeout)}}
+		price := pp.GetPrice(context.Background(), asset.Registry.Pair(denoms.BTC, denoms.NUSD))
+
+		require.Equal(t, types.NewAbstainPrice(asset.Registry.Pair(denoms.BTC, denoms.NUSD), "test", types.AbstainStale), price)
+		require.Equal(t, types.AbstainExchangeRate, price.ExchangeRate())
+	})
+
 	t.Run("Close assertions", func(t *testing.T) {
 		closed := false
 		pp := newPriceProvider(testAsyncSource{
@@ -1,9 +1,9 @@
 package sources
 
 import (
+	"context"
 	"encoding/json"
 	"io"
-	"net/http"
 
 	"github.com/NibiruChain/nibiru/x/common/set"
 	"github.com/NibiruChain/pricefeeder/metrics"
@@ -33,9 +33,9 @@ func BinanceSymbolCsv(symbols set.Set[types.Symbol]) string {
 
 // BinancePriceUpdate returns the prices given the symbols or an error.
 // Uses the Binance API at https://docs.binance.us/#price-data.
-func BinancePriceUpdate(symbols set.Set[types.Symbol], logger zerolog.Logger) (rawPrices map[types.Symbol]float64, err error) {
+func BinancePriceUpdate(ctx context.Context, symbols set.Set[types.Symbol], logger zerolog.Logger) (rawPrices map[types.Symbol]float64, err error) {
 	url := "https://api.binance.us/api/v3/ticker/price?symbols=%5B" + BinanceSymbolCsv(symbols) + "%5D"
-	resp, err := http.Get(url)
+	resp, err := httpGet(ctx, url)
 	if err != nil {
 		logger.Err(err).Msg("failed to fetch prices from Binance")
 		metrics.PriceSourceCounter.WithLabelValues(Binance, "false").Inc()
@@ -1,6 +1,7 @@
 package sources
 
 import (
+	"context"
 	"io"
 	"testing"
 
@@ -12,7 +13,7 @@ import (
 
 func TestBinanceSource(t *testing.T) {
 	t.Run("success", func(t *testing.T) {
-		rawPrices, err := BinancePriceUpdate(set.New[types.Symbol]("BTCUSD", "ETHUSD"), zerolog.New(io.Discard))
+		rawPrices, err := BinancePriceUpdate(context.Background(), set.New[types.Symbol]("BTCUSD", "ETHUSD"), zerolog.New(io.Discard))
 		require.NoError(t, err)
 		require.Equal(t, 2, len(rawPrices))
 		require.NotZero(t, rawPrices["BTCUSD"])
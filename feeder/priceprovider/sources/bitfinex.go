@@ -1,10 +1,10 @@
 package sources
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
-	"net/http"
 
 	"github.com/NibiruChain/nibiru/x/common/set"
 	"github.com/NibiruChain/pricefeeder/metrics"
@@ -27,14 +27,14 @@ func BitfinexSymbolCsv(symbols set.Set[types.Symbol]) string {
 }
 
 // BitfinexPriceUpdate returns the prices given the symbols or an error.
-func BitfinexPriceUpdate(symbols set.Set[types.Symbol], logger zerolog.Logger) (rawPrices map[types.Symbol]float64, err error) {
+func BitfinexPriceUpdate(ctx context.Context, symbols set.Set[types.Symbol], logger zerolog.Logger) (rawPrices map[types.Symbol]float64, err error) {
 	type ticker []interface{}
 	const size = 11
 	const lastPriceIndex = 7
 	const symbolNameIndex = 0
 
 	var url string = "https://api-pub.bitfinex.com/v2/tickers?symbols=" + BitfinexSymbolCsv(symbols)
-	resp, err := http.Get(url)
+	resp, err := httpGet(ctx, url)
 	if err != nil {
 		logger.Err(err).Msg("failed to fetch prices from Bitfinex")
 		metrics.PriceSourceCounter.WithLabelValues(Bitfinex, "false").Inc()
@@ -1,10 +1,10 @@
 package sources
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
-	"net/http"
 	"strconv"
 	"strings"
 
@@ -33,10 +33,10 @@ const ErrBybitBlockAccess = "configured to block access from your country"
 
 // BybitPriceUpdate returns the prices for given symbols or an error.
 // Uses BYBIT API at https://bybit-exchange.github.io/docs/v5/market/tickers.
-func BybitPriceUpdate(symbols set.Set[types.Symbol], logger zerolog.Logger) (rawPrices map[types.Symbol]float64, err error) {
+func BybitPriceUpdate(ctx context.Context, symbols set.Set[types.Symbol], logger zerolog.Logger) (rawPrices map[types.Symbol]float64, err error) {
 	url := "https://api.bybit.com/v5/market/tickers?category=spot"
 
-	resp, err := http.Get(url)
+	resp, err := httpGet(ctx, url)
 	if err != nil {
 		logger.Err(err).Msg("failed to fetch prices from Bybit")
 		metrics.PriceSourceCounter.WithLabelValues(Bybit, "false").Inc()
@@ -1,6 +1,7 @@
 package sources
 
 import (
+	"context"
 	"io"
 	"testing"
 
@@ -12,7 +13,7 @@ import (
 
 func TestBybitPriceUpdate(t *testing.T) {
 	t.Run("success", func(t *testing.T) {
-		rawPrices, err := BybitPriceUpdate(set.New[types.Symbol]("BTCUSDT", "ETHUSDT"), zerolog.New(io.Discard))
+		rawPrices, err := BybitPriceUpdate(context.Background(), set.New[types.Symbol]("BTCUSDT", "ETHUSDT"), zerolog.New(io.Discard))
 		if err != nil {
 			require.ErrorContains(t, err, ErrBybitBlockAccess)
 			return
@@ -1,10 +1,10 @@
 package sources
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
-	"net/http"
 	"net/url"
 
 	"github.com/NibiruChain/nibiru/x/common/set"
@@ -29,7 +29,7 @@ type CoingeckoConfig struct {
 }
 
 func CoingeckoPriceUpdate(sourceConfig json.RawMessage) types.FetchPricesFunc {
-	return func(symbols set.Set[types.Symbol], logger zerolog.Logger) (map[types.Symbol]float64, error) {
+	return func(ctx context.Context, symbols set.Set[types.Symbol], logger zerolog.Logger) (map[types.Symbol]float64, error) {
 		c, err := extractConfig(sourceConfig)
 		if err != nil {
 			logger.Err(err).Msg("failed to extract coingecko config")
@@ -37,7 +37,7 @@ func CoingeckoPriceUpdate(sourceConfig json.RawMessage) types.FetchPricesFunc {
 			return nil, err
 		}
 
-		res, err := http.Get(buildURL(symbols, c))
+		res, err := httpGet(ctx, buildURL(symbols, c))
 		if err != nil {
 			logger.Err(err).Msg("failed to fetch prices from Coingecko")
 			metrics.PriceSourceCounter.WithLabelValues(Coingecko, "false").Inc()
@@ -1,6 +1,7 @@
 package sources
 
 import (
+	"context"
 	"encoding/json"
 	"io"
 	"testing"
@@ -23,6 +24,7 @@ func TestCoingeckoPriceUpdate(t *testing.T) {
 			httpmock.NewStringResponder(200, "{\"bitcoin\":{\"usd\":23829},\"ethereum\":{\"usd\":1676.85}}"),
 		)
 		rawPrices, err := CoingeckoPriceUpdate(json.RawMessage{})(
+			context.Background(),
 			set.New[types.Symbol](
 				"bitcoin",
 				"ethereum",
@@ -60,6 +62,7 @@ func TestCoingeckoWithConfig(t *testing.T) {
 		require.NoError(t, err)
 
 		rawPrices, err := CoingeckoPriceUpdate(jsonOptions)(
+			context.Background(),
 			set.New[types.Symbol](
 				"bitcoin",
 				"ethereum",
@@ -86,6 +89,7 @@ func TestCoingeckoWithConfig(t *testing.T) {
 		require.NoError(t, err)
 
 		rawPrices, err := CoingeckoPriceUpdate(jsonOptions)(
+			context.Background(),
 			set.New[types.Symbol](
 				"bitcoin",
 				"ethereum",
@@ -2,6 +2,7 @@
 package sources
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -42,7 +43,7 @@ type CoinmarketcapConfig struct {
 }
 
 func CoinmarketcapPriceUpdate(coinmarketcapConfig json.RawMessage) types.FetchPricesFunc {
-	return func(symbols set.Set[types.Symbol], logger zerolog.Logger) (map[types.Symbol]float64, error) {
+	return func(ctx context.Context, symbols set.Set[types.Symbol], logger zerolog.Logger) (map[types.Symbol]float64, error) {
 		config, err := getConfig(coinmarketcapConfig)
 		if err != nil {
 			logger.Err(err).Msg("failed to extract coinmarketcap config")
@@ -50,7 +51,7 @@ func CoinmarketcapPriceUpdate(coinmarketcapConfig json.RawMessage) types.FetchPr
 			return nil, err
 		}
 
-		req, err := buildReq(symbols, config)
+		req, err := buildReq(ctx, symbols, config)
 		if err != nil {
 			logger.Err(err).Msg("failed to build request for Coinmarketcap")
 			metrics.PriceSourceCounter.WithLabelValues(CoinMarketCap, "false").Inc()
@@ -123,8 +124,8 @@ func getPricesFromResponse(symbols set.Set[types.Symbol], response []byte, logge
 	return rawPrices, err
 }
 
-func buildReq(symbols set.Set[types.Symbol], c *CoinmarketcapConfig) (*http.Request, error) {
-	req, err := http.NewRequest("GET", link, nil)
+func buildReq(ctx context.Context, symbols set.Set[types.Symbol], c *CoinmarketcapConfig) (*http.Request, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", link, nil)
 	if err != nil {
 		return nil, fmt.Errorf("Can not create a request with link: %s\n", link)
 	}
@@ -1,6 +1,7 @@
 package sources
 
 import (
+	"context"
 	"encoding/json"
 	"io"
 	"testing"
@@ -23,6 +24,7 @@ func TestCoinmarketcapPriceUpdate(t *testing.T) {
 			httpmock.NewStringResponder(200, "{\"status\": {\"error_code\":0},\"data\":{\"1\":{\"slug\":\"bitcoin\",\"quote\":{\"USD\":{\"price\":23829}}}, \"100\":{\"slug\":\"ethereum\",\"quote\":{\"USD\":{\"price\":1676.85}}}}}"),
 		)
 		rawPrices, err := CoinmarketcapPriceUpdate(json.RawMessage{})(
+			context.Background(),
 			set.New[types.Symbol](
 				"bitcoin",
 				"ethereum",
@@ -0,0 +1,22 @@
+package sources
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// ValidateConfig checks that the source exists and that its DATASOURCE_CONFIG_MAP entry can be parsed.
+func ValidateConfig(sourceName string, config json.RawMessage) error {
+	switch sourceName {
+	case Coingecko:
+		_, err := extractConfig(config)
+		return err
+	case CoinMarketCap:
+		_, err := getConfig(config)
+		return err
+	case Bitfinex, Binance, Okex, GateIo, Bybit:
+		return nil
+	default:
+		return fmt.Errorf("unknown price source %q", sourceName)
+	}
+}
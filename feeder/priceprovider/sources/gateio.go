@@ -1,10 +1,10 @@
 package sources
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
-	"net/http"
 	"strconv"
 
 	"github.com/NibiruChain/nibiru/x/common/set"
@@ -21,9 +21,9 @@ var _ types.FetchPricesFunc = GateIoPriceUpdate
 
 // GateIoPriceUpdate returns the prices given the symbols or an error.
 // Uses the GateIo API at https://www.gate.io/docs/developers/apiv4/en/#get-details-of-a-specifc-currency-pair.
-func GateIoPriceUpdate(symbols set.Set[types.Symbol], logger zerolog.Logger) (rawPrices map[types.Symbol]float64, err error) {
+func GateIoPriceUpdate(ctx context.Context, symbols set.Set[types.Symbol], logger zerolog.Logger) (rawPrices map[types.Symbol]float64, err error) {
 	url := "https://api.gateio.ws/api/v4/spot/tickers"
-	resp, err := http.Get(url)
+	resp, err := httpGet(ctx, url)
 	if err != nil {
 		logger.Err(err).Msg("failed to fetch prices from GateIo")
 		metrics.PriceSourceCounter.WithLabelValues(GateIo, "false").Inc()
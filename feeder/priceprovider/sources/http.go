@@ -0,0 +1,15 @@
+package sources
+
+import (
+	"context"
+	"net/http"
+)
+
+// httpGet sends a GET request to the url, which is cancelled when the context is done.
+func httpGet(ctx context.Context, url string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	return http.DefaultClient.Do(req)
+}
@@ -1,10 +1,10 @@
 package sources
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
-	"net/http"
 	"strconv"
 
 	"github.com/NibiruChain/nibiru/x/common/set"
@@ -30,10 +30,10 @@ type OkexResponse struct {
 
 // OkexPriceUpdate returns the prices for given symbols or an error.
 // Uses OKEX API at https://www.okx.com/docs-v5/en/#rest-api-market-data.
-func OkexPriceUpdate(symbols set.Set[types.Symbol], logger zerolog.Logger) (rawPrices map[types.Symbol]float64, err error) {
+func OkexPriceUpdate(ctx context.Context, symbols set.Set[types.Symbol], logger zerolog.Logger) (rawPrices map[types.Symbol]float64, err error) {
 	url := "https://www.okx.com/api/v5/market/tickers?instType=SPOT"
 
-	resp, err := http.Get(url)
+	resp, err := httpGet(ctx, url)
 	if err != nil {
 		logger.Err(err).Msg("failed to fetch prices from Okex")
 		metrics.PriceSourceCounter.WithLabelValues(Okex, "false").Inc()
@@ -1,6 +1,7 @@
 package sources
 
 import (
+	"context"
 	"time"
 
 	"github.com/NibiruChain/nibiru/x/common/set"
@@ -9,6 +10,7 @@ import (
 )
 
 // UpdateTick defines the wait time between price updates.
+// It also bounds every fetch, so that a fetch never runs into the next tick.
 var UpdateTick = 8 * time.Second
 
 var _ types.Source = (*TickSource)(nil)
@@ -16,8 +18,11 @@ var _ types.Source = (*TickSource)(nil)
 // NewTickSource instantiates a new TickSource instance, given the symbols and a price updater function
 // which returns the latest prices for the provided symbols.
 func NewTickSource(symbols set.Set[types.Symbol], fetchPricesFunc types.FetchPricesFunc, logger zerolog.Logger) *TickSource {
+	ctx, cancel := context.WithCancel(context.Background())
 	ts := &TickSource{
 		logger:             logger,
+		ctx:                ctx,
+		cancel:             cancel,
 		stopSignal:         make(chan struct{}),
 		done:               make(chan struct{}),
 		tick:               time.NewTicker(UpdateTick),
@@ -35,11 +40,13 @@ func NewTickSource(symbols set.Set[types.Symbol], fetchPricesFunc types.FetchPri
 // every x time.Duration.
 type TickSource struct {
 	logger             zerolog.Logger
+	ctx                context.Context // cancelled on Close, abandons the fetch in progress
+	cancel             context.CancelFunc
 	stopSignal         chan struct{} // external signal to stop the loop
 	done               chan struct{} // internal signal to wait for shutdown operations
 	tick               *time.Ticker
 	symbols            set.Set[types.Symbol] // symbols as named on the third party data source
-	fetchPrices        func(symbols set.Set[types.Symbol], logger zerolog.Logger) (map[types.Symbol]float64, error)
+	fetchPrices        types.FetchPricesFunc
 	priceUpdateChannel chan map[types.Symbol]types.RawPrice
 }
 
@@ -54,7 +61,9 @@ func (s *TickSource) loop() {
 		case <-s.tick.C:
 			s.logger.Debug().Msg("received tick, updating prices")
 
-			rawPrices, err := s.fetchPrices(s.symbols, s.logger)
+			ctx, cancel := context.WithTimeout(s.ctx, UpdateTick)
+			rawPrices, err := s.fetchPrices(ctx, s.symbols, s.logger)
+			cancel()
 			if err != nil {
 				s.logger.Err(err).Msg("failed to update prices")
 				break // breaks the current select case, not the for cycle
@@ -85,6 +94,7 @@ func (s *TickSource) PriceUpdates() <-chan map[types.Symbol]types.RawPrice {
 }
 
 func (s *TickSource) Close() {
+	s.cancel()
 	close(s.stopSignal)
 	<-s.done
 }
@@ -2,6 +2,7 @@ package sources
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 	"io"
 	"testing"
@@ -27,7 +28,7 @@ func TestTickSource(t *testing.T) {
 		expectedPrices := map[types.Symbol]float64{"tBTCUSDT": 250_000.56}
 
 		ts := NewTickSource(expectedSymbols,
-			func(symbols set.Set[types.Symbol], logger zerolog.Logger) (map[types.Symbol]float64, error) {
+			func(ctx context.Context, symbols set.Set[types.Symbol], logger zerolog.Logger) (map[types.Symbol]float64, error) {
 				require.Equal(t, expectedSymbols, symbols)
 				return expectedPrices, nil
 			}, zerolog.New(io.Discard))
@@ -62,7 +63,7 @@ func TestTickSource(t *testing.T) {
 		expectedSymbols := set.New[types.Symbol]("tBTCUSDT")
 		expectedPrices := map[types.Symbol]float64{"tBTCUSDT": 250_000.56}
 
-		ts := NewTickSource(expectedSymbols, func(symbols set.Set[types.Symbol], logger zerolog.Logger) (map[types.Symbol]float64, error) {
+		ts := NewTickSource(expectedSymbols, func(ctx context.Context, symbols set.Set[types.Symbol], logger zerolog.Logger) (map[types.Symbol]float64, error) {
 			return expectedPrices, nil
 		}, zerolog.New(mw))
 
@@ -81,7 +82,7 @@ func TestTickSource(t *testing.T) {
 			return written, nil
 		}}
 
-		ts := NewTickSource(set.New[types.Symbol]("tBTCUSDT"), func(symbols set.Set[types.Symbol], logger zerolog.Logger) (map[types.Symbol]float64, error) {
+		ts := NewTickSource(set.New[types.Symbol]("tBTCUSDT"), func(ctx context.Context, symbols set.Set[types.Symbol], logger zerolog.Logger) (map[types.Symbol]float64, error) {
 			return nil, fmt.Errorf("sentinel error")
 		}, zerolog.New(mw))
 		defer ts.Close()
@@ -96,4 +97,16 @@ func TestTickSource(t *testing.T) {
 
 		require.Contains(t, logs.String(), "sentinel error") // assert an error was reported
 	})
+	t.Run("close cancels the fetch in progress", func(t *testing.T) {
+		fetchErr := make(chan error, 1)
+		ts := NewTickSource(set.New[types.Symbol]("tBTCUSDT"), func(ctx context.Context, symbols set.Set[types.Symbol], logger zerolog.Logger) (map[types.Symbol]float64, error) {
+			<-ctx.Done()
+			fetchErr <- ctx.Err()
+			return nil, ctx.Err()
+		}, zerolog.New(io.Discard))
+
+		<-time.After(UpdateTick + 100*time.Millisecond) // wait for the fetch to start
+		ts.Close()
+		require.ErrorIs(t, <-fetchErr, context.Canceled)
+	})
 }
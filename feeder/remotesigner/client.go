@@ -0,0 +1,128 @@
+package remotesigner
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/cosmos/cosmos-sdk/crypto/keyring"
+	"github.com/cosmos/cosmos-sdk/crypto/keys/secp256k1"
+	cryptotypes "github.com/cosmos/cosmos-sdk/crypto/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// RequestTimeout bounds every request to the signer.
+var RequestTimeout = 10 * time.Second
+
+// keyName is the name of the remote key in the keyring.
+const keyName = "remote"
+
+var _ keyring.Keyring = (*Client)(nil)
+
+// Client is a keyring.Keyring holding a single key, whose signatures are made by a remote Server.
+// Like the feeder's other keyrings, it only implements the methods used to sign txs;
+// the others are inherited from the nil embedded keyring.Keyring and panic.
+type Client struct {
+	keyring.Keyring
+
+	url        string
+	httpClient *http.Client
+	address    sdk.AccAddress
+	pubKey     cryptotypes.PubKey
+}
+
+// Dial returns a Client for the signer at the given URL, fetching the public key of its key.
+// A nil tlsConfig uses the default TLS configuration for https URLs.
+func Dial(url string, tlsConfig *tls.Config) (*Client, error) {
+	c := &Client{
+		url: strings.TrimSuffix(url, "/"),
+		httpClient: &http.Client{
+			Timeout:   RequestTimeout,
+			Transport: &http.Transport{TLSClientConfig: tlsConfig},
+		},
+	}
+
+	var resp pubKeyResponse
+	if err := c.do(http.MethodGet, pubKeyPath, nil, &resp); err != nil {
+		return nil, fmt.Errorf("failed to get the remote signer public key: %w", err)
+	}
+	if len(resp.PubKey) != secp256k1.PubKeySize {
+		return nil, fmt.Errorf("remote signer returned an invalid secp256k1 public key")
+	}
+	c.pubKey = &secp256k1.PubKey{Key: resp.PubKey}
+	c.address = sdk.AccAddress(c.pubKey.Address())
+	return c, nil
+}
+
+// Address returns the address of the remote key.
+func (c *Client) Address() sdk.AccAddress {
+	return c.address
+}
+
+func (c *Client) Key(uid string) (*keyring.Record, error) {
+	return c.KeyByAddress(c.address)
+}
+
+func (c *Client) KeyByAddress(address sdk.Address) (*keyring.Record, error) {
+	if !address.Equals(c.address) {
+		return nil, fmt.Errorf("key not found: %s", address)
+	}
+	return keyring.NewOfflineRecord(keyName, c.pubKey)
+}
+
+func (c *Client) Sign(uid string, msg []byte) ([]byte, cryptotypes.PubKey, error) {
+	return c.SignByAddress(c.address, msg)
+}
+
+func (c *Client) SignByAddress(address sdk.Address, msg []byte) ([]byte, cryptotypes.PubKey, error) {
+	if !address.Equals(c.address) {
+		return nil, nil, fmt.Errorf("key not found: %s", address)
+	}
+
+	var resp signResponse
+	if err := c.do(http.MethodPost, signPath, signRequest{SignBytes: msg}, &resp); err != nil {
+		return nil, nil, fmt.Errorf("remote signer failed to sign: %w", err)
+	}
+	if !c.pubKey.VerifySignature(msg, resp.Signature) {
+		return nil, nil, fmt.Errorf("remote signer returned an invalid signature")
+	}
+	return resp.Signature, c.pubKey, nil
+}
+
+// do sends the request to the signer and decodes its response.
+func (c *Client) do(method, path string, req, resp any) error {
+	var body bytes.Buffer
+	if req != nil {
+		if err := json.NewEncoder(&body).Encode(req); err != nil {
+			return err
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), RequestTimeout)
+	defer cancel()
+	httpReq, err := http.NewRequestWithContext(ctx, method, c.url+path, &body)
+	if err != nil {
+		return err
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	httpResp, err := c.httpClient.Do(httpReq)
+	if err != nil {
+		return err
+	}
+	defer httpResp.Body.Close()
+
+	if httpResp.StatusCode != http.StatusOK {
+		var errResp errorResponse
+		if err := json.NewDecoder(httpResp.Body).Decode(&errResp); err != nil || errResp.Error == "" {
+			return fmt.Errorf("unexpected status %s", httpResp.Status)
+		}
+		return fmt.Errorf("%s: %s", httpResp.Status, errResp.Error)
+	}
+	return json.NewDecoder(httpResp.Body).Decode(resp)
+}
@@ -0,0 +1,48 @@
+// Package remotesigner lets the feeder sign txs with a key held by a separate signing service.
+//
+// The protocol is JSON over HTTP, meant to be served over mutual TLS:
+//   - GET /v1/pubkey returns the public key of the signing key.
+//   - POST /v1/sign signs the given sign bytes, which must be a SIGN_MODE_DIRECT SignDoc
+//     whose msgs are all allowed by the signer and whose fee is within the signer's cap.
+package remotesigner
+
+import (
+	oracletypes "github.com/NibiruChain/nibiru/x/oracle/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+const (
+	pubKeyPath = "/v1/pubkey"
+	signPath   = "/v1/sign"
+)
+
+// DefaultAllowedMsgs are the msgs the signer signs by default: the ones sent by the feeder.
+var DefaultAllowedMsgs = []string{
+	sdk.MsgTypeURL(&oracletypes.MsgAggregateExchangeRatePrevote{}),
+	sdk.MsgTypeURL(&oracletypes.MsgAggregateExchangeRateVote{}),
+}
+
+// DefaultMaxFee is the highest fee of the txs the signer signs by default,
+// several hundred times the fee of a vote at the default gas prices.
+const DefaultMaxFee = "100000unibi"
+
+// pubKeyResponse is the response of the pubkey endpoint.
+type pubKeyResponse struct {
+	// PubKey is the compressed secp256k1 public key of the signing key.
+	PubKey []byte `json:"pub_key"`
+}
+
+// signRequest is the request of the sign endpoint.
+type signRequest struct {
+	SignBytes []byte `json:"sign_bytes"`
+}
+
+// signResponse is the response of the sign endpoint.
+type signResponse struct {
+	Signature []byte `json:"signature"`
+}
+
+// errorResponse is returned by both endpoints on failure.
+type errorResponse struct {
+	Error string `json:"error"`
+}
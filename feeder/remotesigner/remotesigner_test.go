@@ -0,0 +1,107 @@
+package remotesigner
+
+import (
+	"io"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/NibiruChain/nibiru/app"
+	oracletypes "github.com/NibiruChain/nibiru/x/oracle/types"
+	"github.com/cosmos/cosmos-sdk/client/tx"
+	"github.com/cosmos/cosmos-sdk/crypto/hd"
+	"github.com/cosmos/cosmos-sdk/crypto/keyring"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/tx/signing"
+	authsigning "github.com/cosmos/cosmos-sdk/x/auth/signing"
+	banktypes "github.com/cosmos/cosmos-sdk/x/bank/types"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRemoteSigner(t *testing.T) {
+	app.SetPrefixes(app.AccountAddressPrefix)
+	encoding := app.MakeEncodingConfig()
+	kb := keyring.NewInMemory(encoding.Marshaler)
+	record, _, err := kb.NewMnemonic("feeder", keyring.English, sdk.FullFundraiserPath, "", hd.Secp256k1)
+	require.NoError(t, err)
+	feeder, err := record.GetAddress()
+	require.NoError(t, err)
+	validator := sdk.ValAddress(feeder)
+
+	maxFee := sdk.NewCoins(sdk.NewInt64Coin("unibi", 1_000))
+	server, err := NewServer(kb, feeder, "test-1", DefaultAllowedMsgs, maxFee, zerolog.New(io.Discard))
+	require.NoError(t, err)
+	httpServer := httptest.NewServer(server.Handler())
+	defer httpServer.Close()
+
+	client, err := Dial(httpServer.URL, nil)
+	require.NoError(t, err)
+	require.Equal(t, feeder, client.Address())
+
+	// sign signs a tx made of the given msgs and paying the given fee for the given chain with the remote key.
+	sign := func(chainID string, fee sdk.Coins, msgs ...sdk.Msg) (authsigning.Tx, error) {
+		txBuilder := encoding.TxConfig.NewTxBuilder()
+		require.NoError(t, txBuilder.SetMsgs(msgs...))
+		txBuilder.SetFeeAmount(fee)
+		txFactory := tx.Factory{}.
+			WithChainID(chainID).
+			WithKeybase(client).
+			WithTxConfig(encoding.TxConfig).
+			WithAccountNumber(1).
+			WithSequence(5)
+		err := tx.Sign(txFactory, keyName, txBuilder, true)
+		return txBuilder.GetTx(), err
+	}
+
+	t.Run("oracle msgs", func(t *testing.T) {
+		hash := oracletypes.GetAggregateVoteHash("abcd", "(ubtc:unusd,1.000000000000000000)", validator)
+		signed, err := sign("test-1", maxFee,
+			oracletypes.NewMsgAggregateExchangeRateVote("efgh", "(ubtc:unusd,2.000000000000000000)", feeder, validator),
+			oracletypes.NewMsgAggregateExchangeRatePrevote(hash, feeder, validator),
+		)
+		require.NoError(t, err)
+
+		sigs, err := signed.GetSignaturesV2()
+		require.NoError(t, err)
+		require.Len(t, sigs, 1)
+		signBytes, err := encoding.TxConfig.SignModeHandler().GetSignBytes(signing.SignMode_SIGN_MODE_DIRECT, authsigning.SignerData{
+			ChainID:       "test-1",
+			AccountNumber: 1,
+			Sequence:      5,
+		}, signed)
+		require.NoError(t, err)
+		signature := sigs[0].Data.(*signing.SingleSignatureData).Signature
+		require.True(t, client.pubKey.VerifySignature(signBytes, signature))
+	})
+
+	t.Run("disallowed msg", func(t *testing.T) {
+		_, err := sign("test-1", nil, banktypes.NewMsgSend(feeder, feeder, sdk.NewCoins(sdk.NewInt64Coin("unibi", 1))))
+		require.ErrorContains(t, err, "is not allowed")
+	})
+
+	t.Run("fee above the maximum", func(t *testing.T) {
+		hash := oracletypes.GetAggregateVoteHash("abcd", "(ubtc:unusd,1.000000000000000000)", validator)
+		prevote := oracletypes.NewMsgAggregateExchangeRatePrevote(hash, feeder, validator)
+		_, err := sign("test-1", sdk.NewCoins(sdk.NewInt64Coin("unibi", 1_001)), prevote)
+		require.ErrorContains(t, err, "exceeds the maximum fee")
+		_, err = sign("test-1", sdk.NewCoins(sdk.NewInt64Coin("unusd", 1)), prevote)
+		require.ErrorContains(t, err, "exceeds the maximum fee")
+	})
+
+	t.Run("other chain", func(t *testing.T) {
+		hash := oracletypes.GetAggregateVoteHash("abcd", "(ubtc:unusd,1.000000000000000000)", validator)
+		_, err := sign("test-2", nil, oracletypes.NewMsgAggregateExchangeRatePrevote(hash, feeder, validator))
+		require.ErrorContains(t, err, `not "test-1"`)
+	})
+
+	t.Run("unknown key", func(t *testing.T) {
+		other := sdk.AccAddress(validator.Bytes()[1:])
+		_, _, err := client.SignByAddress(other, []byte("msg"))
+		require.ErrorContains(t, err, "key not found")
+	})
+
+	t.Run("invalid sign bytes", func(t *testing.T) {
+		_, _, err := client.Sign(keyName, []byte("not a sign doc"))
+		require.ErrorContains(t, err, "403 Forbidden")
+	})
+}
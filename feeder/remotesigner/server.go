@@ -0,0 +1,147 @@
+package remotesigner
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/cosmos/cosmos-sdk/crypto/keyring"
+	cryptotypes "github.com/cosmos/cosmos-sdk/crypto/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	txtypes "github.com/cosmos/cosmos-sdk/types/tx"
+	"github.com/rs/zerolog"
+)
+
+// maxRequestSize bounds the size of the sign requests.
+const maxRequestSize = 1 << 20
+
+// Server signs the sign bytes sent by Clients with a local key,
+// as long as every msg of the tx is allowed and its fee is within the cap.
+type Server struct {
+	keyBase     keyring.Keyring
+	address     sdk.AccAddress
+	pubKey      cryptotypes.PubKey
+	chainID     string
+	allowedMsgs map[string]struct{}
+	maxFee      sdk.Coins
+	logger      zerolog.Logger
+}
+
+// NewServer returns a Server signing with the key of the given address.
+// If chainID is not empty, only txs for that chain are signed.
+// If maxFee is not empty, only txs whose fee is at most maxFee in every denom are signed.
+func NewServer(keyBase keyring.Keyring, address sdk.AccAddress, chainID string, allowedMsgs []string, maxFee sdk.Coins, logger zerolog.Logger) (*Server, error) {
+	record, err := keyBase.KeyByAddress(address)
+	if err != nil {
+		return nil, err
+	}
+	pubKey, err := record.GetPubKey()
+	if err != nil {
+		return nil, err
+	}
+
+	allowed := make(map[string]struct{}, len(allowedMsgs))
+	for _, msg := range allowedMsgs {
+		allowed[msg] = struct{}{}
+	}
+
+	return &Server{
+		keyBase:     keyBase,
+		address:     address,
+		pubKey:      pubKey,
+		chainID:     chainID,
+		allowedMsgs: allowed,
+		maxFee:      maxFee,
+		logger:      logger.With().Str("component", "remote-signer").Logger(),
+	}, nil
+}
+
+// Handler returns the HTTP handler serving the remote signer protocol.
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc(pubKeyPath, s.handlePubKey)
+	mux.HandleFunc(signPath, s.handleSign)
+	return mux
+}
+
+func (s *Server) handlePubKey(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, fmt.Errorf("method %s not allowed", r.Method))
+		return
+	}
+	writeJSON(w, http.StatusOK, pubKeyResponse{PubKey: s.pubKey.Bytes()})
+}
+
+func (s *Server) handleSign(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeError(w, http.StatusMethodNotAllowed, fmt.Errorf("method %s not allowed", r.Method))
+		return
+	}
+
+	var req signRequest
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxRequestSize)).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("invalid request: %w", err))
+		return
+	}
+	if err := s.checkSignBytes(req.SignBytes); err != nil {
+		s.logger.Warn().Err(err).Str("remote-addr", r.RemoteAddr).Msg("refused to sign")
+		writeError(w, http.StatusForbidden, err)
+		return
+	}
+
+	signature, _, err := s.keyBase.SignByAddress(s.address, req.SignBytes)
+	if err != nil {
+		s.logger.Err(err).Msg("failed to sign")
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	s.logger.Info().Str("remote-addr", r.RemoteAddr).Msg("signed tx")
+	writeJSON(w, http.StatusOK, signResponse{Signature: signature})
+}
+
+// checkSignBytes returns an error unless the sign bytes are a SignDoc
+// for the expected chain whose msgs are all allowed and whose fee is within the cap.
+func (s *Server) checkSignBytes(signBytes []byte) error {
+	var doc txtypes.SignDoc
+	if err := doc.Unmarshal(signBytes); err != nil {
+		return fmt.Errorf("sign bytes are not a SIGN_MODE_DIRECT sign doc: %w", err)
+	}
+	if s.chainID != "" && doc.ChainId != s.chainID {
+		return fmt.Errorf("tx is for chain %q, not %q", doc.ChainId, s.chainID)
+	}
+
+	var body txtypes.TxBody
+	if err := body.Unmarshal(doc.BodyBytes); err != nil {
+		return fmt.Errorf("invalid tx body: %w", err)
+	}
+	if len(body.Messages) == 0 {
+		return fmt.Errorf("tx has no msgs")
+	}
+	for _, msg := range body.Messages {
+		if _, allowed := s.allowedMsgs[msg.TypeUrl]; !allowed {
+			return fmt.Errorf("msg %s is not allowed", msg.TypeUrl)
+		}
+	}
+
+	if s.maxFee.Empty() {
+		return nil
+	}
+	var authInfo txtypes.AuthInfo
+	if err := authInfo.Unmarshal(doc.AuthInfoBytes); err != nil {
+		return fmt.Errorf("invalid tx auth info: %w", err)
+	}
+	if fee := authInfo.Fee.GetAmount(); !fee.IsAllLTE(s.maxFee) {
+		return fmt.Errorf("fee %s exceeds the maximum fee %s", fee, s.maxFee)
+	}
+	return nil
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, err error) {
+	writeJSON(w, status, errorResponse{Error: err.Error()})
+}
@@ -26,12 +26,12 @@ var AggregatePriceCounter = promauto.NewCounterVec(prometheus.CounterOpts{
 	Help:      "The total number of times prices were aggregated by pair, source, and success status",
 }, []string{"pair", "source", "success"})
 
-// PostedPricesCounter tracks the number of posted prices by success status
+// PostedPricesCounter tracks the number of posted prices by validator and success status
 var PostedPricesCounter = promauto.NewCounterVec(prometheus.CounterOpts{
 	Namespace: PrometheusNamespace,
 	Name:      "prices_posted_total",
-	Help:      "The total number of txs sent to the on-chain oracle module",
-}, []string{"success"})
+	Help:      "The total number of txs sent to the on-chain oracle module, by validator",
+}, []string{"validator", "success"})
 
 // PriceFetchLatency tracks how long it takes to fetch prices from each source
 var PriceFetchLatency = promauto.NewHistogramVec(prometheus.HistogramOpts{
@@ -41,13 +41,100 @@ var PriceFetchLatency = promauto.NewHistogramVec(prometheus.HistogramOpts{
 	Buckets:   []float64{0.01, 0.05, 0.1, 0.5, 1.0, 2.0, 5.0},
 }, []string{"source", "pair"})
 
-// TxBroadcastLatency tracks how long it takes to broadcast transactions
+// TxBroadcastLatency tracks how long it takes for broadcast transactions to be included in a block
 var TxBroadcastLatency = promauto.NewHistogramVec(prometheus.HistogramOpts{
 	Namespace: PrometheusNamespace,
 	Name:      "tx_broadcast_latency_seconds",
-	Help:      "The time it takes to broadcast transactions in seconds",
+	Help:      "The time from the broadcast of transactions to their outcome in seconds",
 	Buckets:   []float64{0.1, 0.5, 1.0, 2.0, 5.0, 10.0, 30.0},
-}, []string{"tx_type"})
+}, []string{"validator", "tx_type", "outcome"})
+
+// VotingPeriodPhaseDuration tracks how long each phase of the processing of a voting period takes
+var VotingPeriodPhaseDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+	Namespace: PrometheusNamespace,
+	Name:      "voting_period_phase_duration_seconds",
+	Help:      "The time it takes to gather prices and to send them in a voting period in seconds, by phase",
+	Buckets:   []float64{0.1, 0.5, 1.0, 2.0, 5.0, 10.0, 30.0},
+}, []string{"phase"})
+
+// AbstainedPrices tracks the pairs abstained from in a vote, by reason
+var AbstainedPrices = promauto.NewCounterVec(prometheus.CounterOpts{
+	Namespace: PrometheusNamespace,
+	Name:      "abstained_prices_total",
+	Help:      "The total number of pairs abstained from in a vote, by pair and reason",
+}, []string{"pair", "reason"})
+
+// PriceSourcesReloads tracks the reloads of the price sources configuration
+var PriceSourcesReloads = promauto.NewCounterVec(prometheus.CounterOpts{
+	Namespace: PrometheusNamespace,
+	Name:      "price_sources_reloads_total",
+	Help:      "The total number of reloads of the price sources configuration, by success",
+}, []string{"success"})
+
+// SkippedVotingPeriods tracks the voting periods for which no prices were sent
+var SkippedVotingPeriods = promauto.NewCounter(prometheus.CounterOpts{
+	Namespace: PrometheusNamespace,
+	Name:      "skipped_voting_periods_total",
+	Help:      "The total number of voting periods for which no prices were sent, because a newer voting period started or the period ended first",
+})
+
+// Leader tracks whether this feeder is the leader of its leader election
+var Leader = promauto.NewGauge(prometheus.GaugeOpts{
+	Namespace: PrometheusNamespace,
+	Name:      "leader",
+	Help:      "Whether this feeder is the leader posting prices (1) or a standby (0), only set with a leader election",
+})
+
+// AccountSequence tracks the locally cached sequence of the accounts signing txs
+var AccountSequence = promauto.NewGaugeVec(prometheus.GaugeOpts{
+	Namespace: PrometheusNamespace,
+	Name:      "account_sequence",
+	Help:      "The current sequence of the accounts signing txs, as tracked by the price feeder",
+}, []string{"address"})
+
+// Oracle Health Metrics
+
+// MissCounter tracks the votes missed by the validator in the current slash window
+var MissCounter = promauto.NewGaugeVec(prometheus.GaugeOpts{
+	Namespace: PrometheusNamespace,
+	Name:      "oracle_miss_counter",
+	Help:      "The number of vote periods missed by the validator in the current slash window",
+}, []string{"validator"})
+
+// AllowedMisses tracks how many vote periods the validator can miss in a slash window without being slashed
+var AllowedMisses = promauto.NewGaugeVec(prometheus.GaugeOpts{
+	Namespace: PrometheusNamespace,
+	Name:      "oracle_allowed_misses",
+	Help:      "The number of vote periods the validator can miss in a slash window without being slashed",
+}, []string{"validator"})
+
+// AggregateVotePresent tracks whether the validator has an aggregate vote in the current vote period
+var AggregateVotePresent = promauto.NewGaugeVec(prometheus.GaugeOpts{
+	Namespace: PrometheusNamespace,
+	Name:      "oracle_aggregate_vote_present",
+	Help:      "Whether the validator has an aggregate vote in the current vote period (1 if present, 0 otherwise)",
+}, []string{"validator"})
+
+// VoteAccepted tracks whether the exchange rate of each pair in the validator's last vote was recorded on chain
+var VoteAccepted = promauto.NewGaugeVec(prometheus.GaugeOpts{
+	Namespace: PrometheusNamespace,
+	Name:      "oracle_vote_accepted",
+	Help:      "Whether the exchange rate of the pair in the validator's last vote was recorded on chain as submitted (1 if accepted, 0 otherwise)",
+}, []string{"validator", "pair"})
+
+// FeederBalance tracks the balance of the account paying the feeder's fees in each fee denom
+var FeederBalance = promauto.NewGaugeVec(prometheus.GaugeOpts{
+	Namespace: PrometheusNamespace,
+	Name:      "feeder_balance",
+	Help:      "The balance of the account paying the feeder's fees in each fee denom",
+}, []string{"address", "denom"})
+
+// RemainingVotes tracks how many more votes the account paying the feeder's fees can pay for at the current fees
+var RemainingVotes = promauto.NewGaugeVec(prometheus.GaugeOpts{
+	Namespace: PrometheusNamespace,
+	Name:      "feeder_remaining_votes",
+	Help:      "The number of votes the account paying the feeder's fees can still pay for at the fees of the last tx",
+}, []string{"address"})
 
 // Data Quality Metrics
 
@@ -102,6 +189,13 @@ var ConnectionStatus = promauto.NewGaugeVec(prometheus.GaugeOpts{
 	Help:      "The status of connections to external services (1 for connected, 0 for disconnected)",
 }, []string{"service_type", "endpoint"})
 
+// ConnectionAttempts tracks the number of failed attempts to (re)connect to external services
+var ConnectionAttempts = promauto.NewCounterVec(prometheus.CounterOpts{
+	Namespace: PrometheusNamespace,
+	Name:      "connection_attempts_total",
+	Help:      "The total number of failed attempts to (re)connect to external services",
+}, []string{"service_type", "endpoint"})
+
 // RateLimitStatus tracks the rate limit status for external APIs
 var RateLimitStatus = promauto.NewGaugeVec(prometheus.GaugeOpts{
 	Namespace: PrometheusNamespace,
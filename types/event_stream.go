@@ -1,5 +1,30 @@
 package types
 
+// ConnectionState describes the state of the connection to the blockchain node.
+type ConnectionState int
+
+const (
+	// ConnectionStateConnecting means the connection is being (re)established.
+	ConnectionStateConnecting ConnectionState = iota
+	// ConnectionStateConnected means the connection is up and events are being received.
+	ConnectionStateConnected
+	// ConnectionStateDisconnected means the connection was lost or closed.
+	ConnectionStateDisconnected
+)
+
+func (s ConnectionState) String() string {
+	switch s {
+	case ConnectionStateConnecting:
+		return "connecting"
+	case ConnectionStateConnected:
+		return "connected"
+	case ConnectionStateDisconnected:
+		return "disconnected"
+	default:
+		return "unknown"
+	}
+}
+
 // EventStream defines the interface for components that connect to the blockchain
 // and listen for relevant events to trigger the price feeder's operations.
 // It monitors chain activity and notifies other components when action is needed.
@@ -14,6 +39,10 @@ type EventStream interface {
 	// This allows the feeder to adjust to changing parameters like vote periods or accepted assets.
 	ParamsUpdate() <-chan Params
 
+	// ConnectionStateChanged returns a channel that signals transitions of the
+	// connection to the blockchain, so consumers can decide how to react to outages.
+	ConnectionStateChanged() <-chan ConnectionState
+
 	// Close shuts down the event stream and cleans up any connections to the blockchain.
 	Close()
 }
@@ -0,0 +1,18 @@
+package types
+
+import "context"
+
+// LeaderElection elects a single leader among feeders running for the same validators,
+// so that only one of them posts prices while the others stand by.
+// LeaderElection must handle failures by itself, a feeder unable to tell whether it leads must not lead.
+//
+//go:generate mockgen --destination mocks/leader_election.go . LeaderElection
+type LeaderElection interface {
+	// IsLeader reports whether this feeder currently holds the leadership.
+	IsLeader() bool
+	// Leading returns a copy of ctx which is cancelled as soon as this feeder no longer leads,
+	// or right away if it does not.
+	Leading(ctx context.Context) (context.Context, context.CancelFunc)
+	// Close gives up the leadership, if held, and stops taking part in the election.
+	Close()
+}
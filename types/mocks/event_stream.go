@@ -46,6 +46,20 @@ func (mr *MockEventStreamMockRecorder) Close() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Close", reflect.TypeOf((*MockEventStream)(nil).Close))
 }
 
+// ConnectionStateChanged mocks base method.
+func (m *MockEventStream) ConnectionStateChanged() <-chan types.ConnectionState {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConnectionStateChanged")
+	ret0, _ := ret[0].(<-chan types.ConnectionState)
+	return ret0
+}
+
+// ConnectionStateChanged indicates an expected call of ConnectionStateChanged.
+func (mr *MockEventStreamMockRecorder) ConnectionStateChanged() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConnectionStateChanged", reflect.TypeOf((*MockEventStream)(nil).ConnectionStateChanged))
+}
+
 // ParamsUpdate mocks base method.
 func (m *MockEventStream) ParamsUpdate() <-chan types.Params {
 	m.ctrl.T.Helper()
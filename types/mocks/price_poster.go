@@ -5,6 +5,7 @@
 package mock_types
 
 import (
+	context "context"
 	reflect "reflect"
 
 	types "github.com/NibiruChain/pricefeeder/types"
@@ -48,15 +49,18 @@ func (mr *MockPricePosterMockRecorder) Close() *gomock.Call {
 }
 
 // SendPrices mocks base method.
-func (m *MockPricePoster) SendPrices(arg0 types.VotingPeriod, arg1 []types.Price) {
+func (m *MockPricePoster) SendPrices(arg0 context.Context, arg1 types.VotingPeriod, arg2 []types.Price) (types.PostResult, error) {
 	m.ctrl.T.Helper()
-	m.ctrl.Call(m, "SendPrices", arg0, arg1)
+	ret := m.ctrl.Call(m, "SendPrices", arg0, arg1, arg2)
+	ret0, _ := ret[0].(types.PostResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
 // SendPrices indicates an expected call of SendPrices.
-func (mr *MockPricePosterMockRecorder) SendPrices(arg0, arg1 interface{}) *gomock.Call {
+func (mr *MockPricePosterMockRecorder) SendPrices(arg0, arg1, arg2 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendPrices", reflect.TypeOf((*MockPricePoster)(nil).SendPrices), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendPrices", reflect.TypeOf((*MockPricePoster)(nil).SendPrices), arg0, arg1, arg2)
 }
 
 // Whoami mocks base method.
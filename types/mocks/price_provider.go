@@ -5,6 +5,7 @@
 package mock_types
 
 import (
+	context "context"
 	reflect "reflect"
 
 	asset "github.com/NibiruChain/nibiru/x/common/asset"
@@ -48,15 +49,15 @@ func (mr *MockPriceProviderMockRecorder) Close() *gomock.Call {
 }
 
 // GetPrice mocks base method.
-func (m *MockPriceProvider) GetPrice(arg0 asset.Pair) types.Price {
+func (m *MockPriceProvider) GetPrice(arg0 context.Context, arg1 asset.Pair) types.Price {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetPrice", arg0)
+	ret := m.ctrl.Call(m, "GetPrice", arg0, arg1)
 	ret0, _ := ret[0].(types.Price)
 	return ret0
 }
 
 // GetPrice indicates an expected call of GetPrice.
-func (mr *MockPriceProviderMockRecorder) GetPrice(arg0 interface{}) *gomock.Call {
+func (mr *MockPriceProviderMockRecorder) GetPrice(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPrice", reflect.TypeOf((*MockPriceProvider)(nil).GetPrice), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPrice", reflect.TypeOf((*MockPriceProvider)(nil).GetPrice), arg0, arg1)
 }
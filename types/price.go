@@ -1,6 +1,7 @@
 package types
 
 import (
+	"context"
 	"time"
 
 	"github.com/NibiruChain/nibiru/x/common/asset"
@@ -32,6 +33,53 @@ type Price struct {
 	// If not valid then an abstain vote will be posted.
 	// Computed from the update time.
 	Valid bool
+	// AbstainReason reports why an invalid price is abstained from.
+	// It is empty for valid prices.
+	AbstainReason AbstainReason
+}
+
+// AbstainExchangeRate is the exchange rate the oracle module reads as an abstain vote.
+const AbstainExchangeRate = 0.0
+
+// AbstainReason explains why no price is voted for a pair.
+type AbstainReason string
+
+const (
+	// AbstainNotConfigured is used for pairs with no symbol configured on any exchange.
+	AbstainNotConfigured AbstainReason = "not_configured"
+	// AbstainStale is used for pairs whose last price is missing or older than PriceTimeout.
+	AbstainStale AbstainReason = "stale"
+	// AbstainQuorumFailed is reserved for when too few sources agree on a price. Not emitted yet.
+	AbstainQuorumFailed AbstainReason = "quorum_failed"
+	// AbstainOutlier is reserved for when a price deviates too much from the other sources. Not emitted yet.
+	AbstainOutlier AbstainReason = "outlier"
+	// AbstainCircuitOpen is reserved for when the source of a price is disabled after repeated failures.
+	// Not emitted yet.
+	AbstainCircuitOpen AbstainReason = "circuit_open"
+	// AbstainTimeout is used for pairs whose price was not gathered in time.
+	AbstainTimeout AbstainReason = "timeout"
+	// AbstainUnknown is used for invalid prices which carry no reason.
+	AbstainUnknown AbstainReason = "unknown"
+)
+
+// NewAbstainPrice returns the invalid price of an abstain vote on the given pair.
+func NewAbstainPrice(pair asset.Pair, sourceName string, reason AbstainReason) Price {
+	return Price{
+		Pair:          pair,
+		Price:         AbstainExchangeRate,
+		SourceName:    sourceName,
+		Valid:         false,
+		AbstainReason: reason,
+	}
+}
+
+// ExchangeRate returns the exchange rate voted for the price,
+// which is AbstainExchangeRate if the price is invalid.
+func (p Price) ExchangeRate() float64 {
+	if !p.Valid {
+		return AbstainExchangeRate
+	}
+	return p.Price
 }
 
 // FetchPricesFunc is the function type used to fetch updated prices from an exchange.
@@ -40,4 +88,5 @@ type Price struct {
 // The returned map must map symbol to its float64 price, or an error.
 // If there's a failure in updating only one price then the map can be returned
 // without the provided symbol.
-type FetchPricesFunc func(symbols set.Set[Symbol], logger zerolog.Logger) (map[Symbol]float64, error)
+// The fetch must be abandoned once the context is done.
+type FetchPricesFunc func(ctx context.Context, symbols set.Set[Symbol], logger zerolog.Logger) (map[Symbol]float64, error)
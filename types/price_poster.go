@@ -1,17 +1,46 @@
 package types
 
 import (
+	"context"
+
 	sdk "github.com/cosmos/cosmos-sdk/types"
 )
 
+// PostStatus is the status of the tx sent by PricePoster.SendPrices.
+type PostStatus string
+
+const (
+	// PostStatusIncluded means the tx was included in a block.
+	PostStatusIncluded PostStatus = "included"
+	// PostStatusPending means the tx was accepted by the node but not included before the context was done,
+	// it might still be included later.
+	PostStatusPending PostStatus = "pending"
+	// PostStatusFailed means the tx was not accepted by the node, or was included but failed.
+	PostStatusFailed PostStatus = "failed"
+	// PostStatusDryRun means the tx was recorded instead of being broadcast.
+	PostStatusDryRun PostStatus = "dry_run"
+)
+
+// PostResult describes the tx sent by PricePoster.SendPrices.
+type PostResult struct {
+	// TxHash is the hash of the tx, empty if it was not broadcast.
+	TxHash string
+	// Height is the height of the block the tx was included in, zero if it was not included.
+	Height int64
+	// Status is the status of the tx.
+	Status PostStatus
+}
+
 // PricePoster defines the interface for components that submit prices to the blockchain.
 // It handles the creation and submission of oracle vote transactions.
 //
 //go:generate mockgen --destination mocks/price_poster.go . PricePoster
 type PricePoster interface {
 	// SendPrices broadcasts price data to the blockchain for the current voting period.
-	// It creates the necessary transactions to participate in the oracle voting protocol.
-	SendPrices(vp VotingPeriod, prices []Price)
+	// It creates the necessary transactions to participate in the oracle voting protocol,
+	// and gives up once the context is done.
+	// The returned error is set if the tx was not included.
+	SendPrices(ctx context.Context, vp VotingPeriod, prices []Price) (PostResult, error)
 
 	// Whoami returns the validator address that this poster is submitting prices for.
 	Whoami() sdk.ValAddress
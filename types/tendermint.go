@@ -2,52 +2,194 @@ package types
 
 import (
 	"encoding/json"
+	"fmt"
 	"strconv"
 	"time"
 )
 
-func GetBlockHeight(msg []byte) (uint64, error) {
+// EventFormat identifies the layout of the block events
+// shipped by the CometBFT node alongside a new block.
+type EventFormat int
+
+const (
+	// EventFormatUnknown is used when the message carries no block events,
+	// for example the subscription acknowledgement.
+	EventFormatUnknown EventFormat = iota
+	// EventFormatBeginEndBlock is the CometBFT <= 0.37 layout, which splits
+	// events across result_begin_block and result_end_block.
+	EventFormatBeginEndBlock
+	// EventFormatFinalizeBlock is the CometBFT >= 0.38 layout, which ships
+	// all the block events in result_finalize_block.
+	EventFormatFinalizeBlock
+)
+
+func (f EventFormat) String() string {
+	switch f {
+	case EventFormatBeginEndBlock:
+		return "begin-end-block"
+	case EventFormatFinalizeBlock:
+		return "finalize-block"
+	default:
+		return "unknown"
+	}
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (f EventFormat) MarshalText() ([]byte, error) {
+	return []byte(f.String()), nil
+}
+
+// BlockHeader is the subset of a block header the price feeder cares about.
+type BlockHeader struct {
+	ChainID string
+	Height  uint64
+	Time    time.Time
+}
+
+// NewBlock is the format independent representation
+// of a NewBlock or NewBlockHeader event.
+type NewBlock struct {
+	Header BlockHeader
+	// Format reports the event layout the block was parsed from.
+	Format EventFormat
+	// Events contains the block level events in execution order,
+	// for EventFormatBeginEndBlock begin block events come first.
+	Events []TmEvent
+}
+
+// ParseNewBlock parses a NewBlock or NewBlockHeader event message, automatically
+// detecting whether it was produced by a CometBFT 0.37 or 0.38 node.
+// Messages which carry no block, like the subscription acknowledgement,
+// yield an empty NewBlock.
+func ParseNewBlock(msg []byte) (NewBlock, error) {
 	t := new(NewBlockJSON)
 	err := json.Unmarshal(msg, t)
 	if err != nil {
-		return 0, err
+		return NewBlock{}, err
+	}
+	value := t.Result.Data.Value
+	tmHeader := value.Block.Header
+	if value.Header != nil {
+		tmHeader = *value.Header
 	}
-	if t.Result.Data.Value.Block.Header.Height == "" {
-		return 0, nil
+	if tmHeader.Height == "" {
+		return NewBlock{}, nil
+	}
+
+	header, err := tmHeader.toBlockHeader()
+	if err != nil {
+		return NewBlock{}, err
+	}
+
+	block := NewBlock{Header: header}
+	switch {
+	case value.ResultFinalizeBlock != nil:
+		block.Format = EventFormatFinalizeBlock
+		block.Events = value.ResultFinalizeBlock.Events
+	case value.ResultBeginBlock != nil || value.ResultEndBlock != nil:
+		block.Format = EventFormatBeginEndBlock
+		if value.ResultBeginBlock != nil {
+			block.Events = append(block.Events, value.ResultBeginBlock.Events...)
+		}
+		if value.ResultEndBlock != nil {
+			block.Events = append(block.Events, value.ResultEndBlock.Events...)
+		}
+	}
+	return block, nil
+}
+
+// GetBlockHeader parses the block header out of a NewBlock or NewBlockHeader event message.
+func GetBlockHeader(msg []byte) (BlockHeader, error) {
+	block, err := ParseNewBlock(msg)
+	if err != nil {
+		return BlockHeader{}, err
+	}
+	return block.Header, nil
+}
+
+func GetBlockHeight(msg []byte) (uint64, error) {
+	header, err := GetBlockHeader(msg)
+	if err != nil {
+		return 0, err
 	}
-	return strconv.ParseUint(t.Result.Data.Value.Block.Header.Height, 10, 64)
+	return header.Height, nil
 }
 
-// todo mercilex split in concrete types instead of anonymous
+// NewBlockJSON is the JSON-RPC message pushed by the node
+// for a tm.event='NewBlock' or tm.event='NewBlockHeader' subscription.
 type NewBlockJSON struct {
-	Jsonrpc string `json:"jsonrpc"`
-	ID      int    `json:"id"`
-	Result  struct {
-		Query string `json:"query"`
-		Data  struct {
-			Type  string `json:"type"`
-			Value struct {
-				Block struct {
-					Header struct {
-						ChainID        string    `json:"chain_id"`
-						Height         string    `json:"height"`
-						Time           time.Time `json:"time"`
-						LastCommitHash string    `json:"last_commit_hash"`
-					} `json:"header"`
-					Data struct {
-						Txs []interface{} `json:"txs"`
-					} `json:"data"`
-				} `json:"block"`
-				ResultBeginBlock struct {
-					Events []TmEvent `json:"events"`
-				} `json:"result_begin_block"`
-				ResultEndBlock struct {
-					ValidatorUpdates []interface{} `json:"validator_updates"`
-					Events           []TmEvent     `json:"events"`
-				} `json:"result_end_block"`
-			} `json:"value"`
-		} `json:"data"`
-	} `json:"result"`
+	Jsonrpc string         `json:"jsonrpc"`
+	ID      int            `json:"id"`
+	Result  NewBlockResult `json:"result"`
+}
+
+type NewBlockResult struct {
+	Query string       `json:"query"`
+	Data  NewBlockData `json:"data"`
+}
+
+type NewBlockData struct {
+	Type  string        `json:"type"`
+	Value NewBlockValue `json:"value"`
+}
+
+// NewBlockValue holds the block and its events, exactly one of
+// ResultFinalizeBlock or ResultBeginBlock/ResultEndBlock is set
+// depending on the CometBFT version of the node.
+// NewBlockHeader events carry only the Header, and on
+// CometBFT >= 0.38 no events at all.
+type NewBlockValue struct {
+	Block  TmBlock   `json:"block"`
+	Header *TmHeader `json:"header,omitempty"`
+	// CometBFT <= 0.37
+	ResultBeginBlock *TmResultBeginBlock `json:"result_begin_block,omitempty"`
+	ResultEndBlock   *TmResultEndBlock   `json:"result_end_block,omitempty"`
+	// CometBFT >= 0.38
+	ResultFinalizeBlock *TmResultFinalizeBlock `json:"result_finalize_block,omitempty"`
+}
+
+type TmBlock struct {
+	Header TmHeader    `json:"header"`
+	Data   TmBlockData `json:"data"`
+}
+
+type TmHeader struct {
+	ChainID        string    `json:"chain_id"`
+	Height         string    `json:"height"`
+	Time           time.Time `json:"time"`
+	LastCommitHash string    `json:"last_commit_hash"`
+}
+
+func (h TmHeader) toBlockHeader() (BlockHeader, error) {
+	height, err := strconv.ParseUint(h.Height, 10, 64)
+	if err != nil {
+		return BlockHeader{}, fmt.Errorf("invalid block height %q: %w", h.Height, err)
+	}
+	return BlockHeader{
+		ChainID: h.ChainID,
+		Height:  height,
+		Time:    h.Time,
+	}, nil
+}
+
+type TmBlockData struct {
+	Txs []interface{} `json:"txs"`
+}
+
+type TmResultBeginBlock struct {
+	Events []TmEvent `json:"events"`
+}
+
+type TmResultEndBlock struct {
+	ValidatorUpdates []interface{} `json:"validator_updates"`
+	Events           []TmEvent     `json:"events"`
+}
+
+type TmResultFinalizeBlock struct {
+	Events           []TmEvent     `json:"events"`
+	TxResults        []interface{} `json:"tx_results"`
+	ValidatorUpdates []interface{} `json:"validator_updates"`
+	AppHash          string        `json:"app_hash"`
 }
 
 type TmEvent struct {
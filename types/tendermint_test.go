@@ -0,0 +1,64 @@
+package types
+
+import (
+	"encoding/json"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var update = flag.Bool("update", false, "update the golden files")
+
+func TestParseNewBlock(t *testing.T) {
+	testCases := []struct {
+		name   string
+		file   string
+		format EventFormat
+		height uint64
+	}{
+		{name: "cometbft 0.37", file: "new_block_v0.37", format: EventFormatBeginEndBlock, height: 119},
+		{name: "cometbft 0.38", file: "new_block_v0.38", format: EventFormatFinalizeBlock, height: 240},
+		{name: "cometbft 0.37 header", file: "new_block_header_v0.37", format: EventFormatBeginEndBlock, height: 120},
+		{name: "cometbft 0.38 header", file: "new_block_header_v0.38", format: EventFormatUnknown, height: 241},
+		{name: "subscription ack", file: "subscribe_ack", format: EventFormatUnknown, height: 0},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			msg, err := os.ReadFile(filepath.Join("testdata", tc.file+".json"))
+			require.NoError(t, err)
+
+			block, err := ParseNewBlock(msg)
+			require.NoError(t, err)
+			require.Equal(t, tc.format, block.Format)
+			require.Equal(t, tc.height, block.Header.Height)
+
+			height, err := GetBlockHeight(msg)
+			require.NoError(t, err)
+			require.Equal(t, tc.height, height)
+
+			got, err := json.MarshalIndent(block, "", "  ")
+			require.NoError(t, err)
+			golden := filepath.Join("testdata", tc.file+".golden")
+			if *update {
+				require.NoError(t, os.WriteFile(golden, got, 0o644))
+			}
+			want, err := os.ReadFile(golden)
+			require.NoError(t, err)
+			require.JSONEq(t, string(want), string(got))
+		})
+	}
+
+	t.Run("invalid height", func(t *testing.T) {
+		_, err := ParseNewBlock([]byte(`{"result":{"data":{"value":{"block":{"header":{"height":"abc"}}}}}}`))
+		require.ErrorContains(t, err, "invalid block height")
+	})
+
+	t.Run("invalid json", func(t *testing.T) {
+		_, err := ParseNewBlock([]byte(`{`))
+		require.Error(t, err)
+	})
+}
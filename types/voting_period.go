@@ -1,8 +1,30 @@
 package types
 
+import "time"
+
 // VotingPeriod contains information
 // concerning the current voting period.
 type VotingPeriod struct {
 	// Height is the height of the voting period.
 	Height uint64
+	// EndHeight is the last height which belongs to the voting period,
+	// after which the next voting period starts.
+	EndHeight uint64
+	// BlockTime is the time of the block which triggered the voting period.
+	BlockTime time.Time
+	// Deadline is the estimated time at which the voting period ends,
+	// derived from the average time of the recent blocks.
+	// It is zero if there were not enough blocks to estimate it.
+	Deadline time.Time
+	// ChainID is the chain identifier reported by the block header.
+	ChainID string
+}
+
+// TimeLeft returns how much time is left before the voting period's
+// deadline is reached, it is zero if the deadline is not known or has expired.
+func (vp VotingPeriod) TimeLeft(now time.Time) time.Duration {
+	if vp.Deadline.IsZero() || !now.Before(vp.Deadline) {
+		return 0
+	}
+	return vp.Deadline.Sub(now)
 }